@@ -0,0 +1,294 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/author"
+)
+
+var (
+	authorLookupName        string
+	authorLookupMapper      string
+	authorLookupAllowCreate bool
+	authorLookupFilter      padFilterFlags
+	authorLookupOutput      string
+	authorLookupConcurrency int
+
+	authorExportFile        string
+	authorExportConcurrency int
+
+	authorAnonymizeMapper      string
+	authorAnonymizeName        string
+	authorAnonymizeRemoveChat  bool
+	authorAnonymizeReport      string
+	authorAnonymizeDryRun      bool
+	authorAnonymizeYes         bool
+	authorAnonymizeConcurrency int
+
+	authorLongDescription = `
+The commands look up the authors of Etherpad, list and export everything they wrote and anonymize them, e.g. to
+answer data subject requests.
+
+Example:
+
+etherpad-toolkit author lookup --name alice
+etherpad-toolkit author lookup --mapper user-42 --allow-create
+etherpad-toolkit author pads a.Xk3mPq9dRt5sLw2B
+etherpad-toolkit author export a.Xk3mPq9dRt5sLw2B --file alice.json
+etherpad-toolkit author anonymize a.Xk3mPq9dRt5sLw2B --mapper user-42 --remove-chat --report report.json
+`
+
+	authorLookupLongDescription = `
+The command looks up authors by name or by mapper. Etherpad has no index of the authors, so for the lookup by name
+the authors of all pads, or of the pads matching the filter, are fetched and their names are matched case-insensitive.
+The mapper is the ID of the author in another system, e.g. the user ID of a single sign-on. Etherpad has no lookup by
+mapper without side effects, it creates a new empty author if the mapper is unknown. Therefore the lookup by mapper
+requires --allow-create and warns if the author has no name and no pads.
+
+Example:
+
+etherpad-toolkit author lookup --name alice --prefix team-
+etherpad-toolkit author lookup --mapper user-42 --allow-create --output json
+`
+
+	authorExportLongDescription = `
+The command exports everything an author wrote as JSON: the name, the parts of the current text which are attributed
+to the author, the revisions of the author and the chat messages of every pad the author contributed to. The pads are
+downloaded in the .etherpad format, so the export endpoint must be reachable for the toolkit.
+
+Example:
+
+etherpad-toolkit author export a.Xk3mPq9dRt5sLw2B --file alice.json
+`
+
+	authorAnonymizeLongDescription = `
+The command anonymizes an author. The name is replaced, which is only possible with the mapper of the author. With
+--remove-chat the chat messages of the author are removed from all pads the author contributed to: the pads are
+downloaded in the .etherpad format and replaced by an import without the messages, the text and the history are kept.
+Users which have the pad open while it is rewritten may lose their latest changes. The rewrite is confirmed before,
+unless --yes or --dry-run is set.
+
+The mapper is checked before the name is replaced. Etherpad creates a new empty author for an unknown mapper, e.g. a
+mistyped one. The API can not delete authors, so the empty author is left behind.
+
+The command writes an auditable report as JSON which contains no personal data besides the IDs.
+
+Example:
+
+etherpad-toolkit author anonymize a.Xk3mPq9dRt5sLw2B --mapper user-42 --remove-chat --report report.json
+`
+
+	authorCmd = NewAuthorCmd()
+)
+
+func init() {
+	rootCmd.AddCommand(authorCmd)
+}
+
+func NewAuthorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "author",
+		Short: "Looks up, exports and anonymizes Authors",
+		Long:  authorLongDescription,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Print(cmd.UsageString())
+		},
+	}
+
+	cmd.AddCommand(NewAuthorLookupCmd())
+	cmd.AddCommand(NewAuthorPadsCmd())
+	cmd.AddCommand(NewAuthorExportCmd())
+	cmd.AddCommand(NewAuthorAnonymizeCmd())
+
+	return cmd
+}
+
+func NewAuthorLookupCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lookup",
+		Short: "Looks up Authors by name or mapper",
+		Long:  authorLookupLongDescription,
+		Run: func(cmd *cobra.Command, args []string) {
+			if (authorLookupName == "") == (authorLookupMapper == "") {
+				cmd.Print(cmd.UsageString())
+				return
+			}
+
+			etherpad := pkg.NewEtherpadClient(etherpadUrl, etherpadApiKey)
+			finder := author.NewFinder(etherpad)
+			finder.Concurrency = authorLookupConcurrency
+
+			var authors []author.Author
+			if authorLookupMapper != "" {
+				if !authorLookupAllowCreate {
+					log.Error("--allow-create is required, Etherpad creates an author if the mapper is unknown")
+					return
+				}
+				found, err := finder.FindByMapper(authorLookupMapper)
+				if err != nil {
+					log.WithError(err).Error("failed to look up author")
+					return
+				}
+				if found.Empty() {
+					log.WithField("author", found.ID).Warn("author has no name and no pads, it was probably created for an unknown mapper")
+				}
+				authors = append(authors, found)
+			} else {
+				pads, err := selectPads(etherpad, args, authorLookupFilter)
+				if err != nil {
+					log.WithError(err).Error("failed to select pads")
+					return
+				}
+				authors = finder.FindByName(authorLookupName, pads)
+			}
+
+			if err := author.Write(cmd.OutOrStdout(), authorLookupOutput, authors); err != nil {
+				log.WithError(err).Error("failed to write authors")
+			}
+		},
+	}
+
+	authorLookupFilter.register(cmd)
+	cmd.Flags().StringVar(&authorLookupName, "name", "", "Look up the authors whose name contains the value.")
+	cmd.Flags().StringVar(&authorLookupMapper, "mapper", "", "Look up the author of the mapper.")
+	cmd.Flags().BoolVar(&authorLookupAllowCreate, "allow-create", false, "Allow the lookup by mapper, which creates an author if the mapper is unknown.")
+	cmd.Flags().StringVarP(&authorLookupOutput, "output", "o", author.FormatTable, "Output format: table or json")
+	cmd.Flags().IntVar(&authorLookupConcurrency, "concurrency", 4, "Concurrency for fetching the authors")
+
+	return cmd
+}
+
+func NewAuthorPadsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "pads [authorID]",
+		Short: "Lists the Pads of an Author",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				cmd.Print(cmd.UsageString())
+				return
+			}
+
+			pads, err := pkg.NewEtherpadClient(etherpadUrl, etherpadApiKey).ListPadsOfAuthor(args[0])
+			if err != nil {
+				log.WithError(err).WithField("author", args[0]).Error("failed to list pads")
+				return
+			}
+
+			for _, pad := range pads {
+				cmd.Println(pad)
+			}
+		},
+	}
+}
+
+func NewAuthorExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [authorID]",
+		Short: "Exports everything an Author wrote",
+		Long:  authorExportLongDescription,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				cmd.Print(cmd.UsageString())
+				return
+			}
+
+			finder := author.NewFinder(pkg.NewEtherpadClient(etherpadUrl, etherpadApiKey))
+			finder.Concurrency = authorExportConcurrency
+
+			data, err := finder.Export(args[0])
+			if err != nil {
+				log.WithError(err).WithField("author", args[0]).Error("failed to export author")
+				return
+			}
+
+			if err = writeJSON(cmd.OutOrStdout(), authorExportFile, data); err != nil {
+				log.WithError(err).Error("failed to write export")
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&authorExportFile, "file", "f", "", "File to write the export to. Stdout if empty.")
+	cmd.Flags().IntVar(&authorExportConcurrency, "concurrency", 4, "Concurrency for the export process")
+
+	return cmd
+}
+
+func NewAuthorAnonymizeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "anonymize [authorID]",
+		Short: "Anonymizes an Author",
+		Long:  authorAnonymizeLongDescription,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				cmd.Print(cmd.UsageString())
+				return
+			}
+
+			etherpad := pkg.NewEtherpadClient(etherpadUrl, etherpadApiKey)
+			if authorAnonymizeRemoveChat && !authorAnonymizeYes && !authorAnonymizeDryRun {
+				pads, err := etherpad.ListPadsOfAuthor(args[0])
+				if err != nil {
+					log.WithError(err).WithField("author", args[0]).Error("failed to list pads")
+					return
+				}
+				if len(pads) > 0 && !confirm(cmd, fmt.Sprintf("Rewrite %d pads to remove the chat messages?", len(pads))) {
+					log.Info("aborted")
+					return
+				}
+			}
+
+			anonymizer := author.NewAnonymizer(etherpad, authorAnonymizeDryRun)
+			anonymizer.Name = authorAnonymizeName
+			anonymizer.Concurrency = authorAnonymizeConcurrency
+
+			report, err := anonymizer.Anonymize(args[0], authorAnonymizeMapper, authorAnonymizeRemoveChat)
+			if err != nil {
+				log.WithError(err).WithField("author", args[0]).Error("failed to anonymize author")
+				return
+			}
+			if report.Note != "" {
+				log.WithField("author", args[0]).Warn(report.Note)
+			}
+
+			if err = writeJSON(cmd.OutOrStdout(), authorAnonymizeReport, report); err != nil {
+				log.WithError(err).Error("failed to write report")
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&authorAnonymizeMapper, "mapper", "", "Mapper of the author, required to replace the name.")
+	cmd.Flags().StringVar(&authorAnonymizeName, "name", "Anonymous", "Name which replaces the name of the author.")
+	cmd.Flags().BoolVar(&authorAnonymizeRemoveChat, "remove-chat", false, "Remove the chat messages of the author by rewriting the pads.")
+	cmd.Flags().StringVar(&authorAnonymizeReport, "report", "", "File to write the report to. Stdout if empty.")
+	cmd.Flags().BoolVar(&authorAnonymizeDryRun, "dry-run", false, "Enable dry-run")
+	cmd.Flags().BoolVarP(&authorAnonymizeYes, "yes", "y", false, "Rewrite the pads without confirmation.")
+	cmd.Flags().IntVar(&authorAnonymizeConcurrency, "concurrency", 4, "Concurrency for the anonymize process")
+
+	return cmd
+}
+
+// writeJSON writes the value as JSON to the file or to w if the file is empty.
+func writeJSON(w io.Writer, file string, v interface{}) error {
+	if file == "" {
+		return author.WriteJSON(w, v)
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	if err = author.WriteJSON(f, v); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+
+	return nil
+}
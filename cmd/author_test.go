@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAuthorCmd(t *testing.T) {
+	cmd := NewAuthorCmd()
+	cmd.SetArgs([]string{})
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	err := cmd.Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, cmd.UsageString(), string(out))
+}
+
+func TestNewAuthorLookupCmd(t *testing.T) {
+	cmd := NewAuthorLookupCmd()
+	cmd.SetArgs([]string{})
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	err := cmd.Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, cmd.UsageString(), string(out))
+}
+
+func TestAuthorLookupCmd_Mapper(t *testing.T) {
+	var mu sync.Mutex
+	var created []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(r.URL.Path, "/")
+		switch parts[len(parts)-1] {
+		case "createAuthorIfNotExistsFor":
+			mu.Lock()
+			created = append(created, r.FormValue("authorMapper"))
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"authorID": "a.alice"}}`))
+		case "getAuthorName":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": "Alice"}`))
+		case "listPadsOfAuthor":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"padIDs": ["notes"]}}`))
+		}
+	}))
+	defer ts.Close()
+
+	url := etherpadUrl
+	etherpadUrl = ts.URL
+	defer func() { etherpadUrl = url }()
+	defer func() { authorLookupMapper, authorLookupAllowCreate = "", false }()
+
+	// the lookup by mapper may create an author, so it has to be allowed
+	cmd := NewAuthorLookupCmd()
+	cmd.SetArgs([]string{"--mapper", "user-alice"})
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+
+	assert.Nil(t, cmd.Execute())
+	assert.Empty(t, b.String())
+	assert.Empty(t, created)
+
+	cmd = NewAuthorLookupCmd()
+	cmd.SetArgs([]string{"--mapper", "user-alice", "--allow-create"})
+	cmd.SetOut(b)
+
+	assert.Nil(t, cmd.Execute())
+	assert.Equal(t, "AUTHOR   NAME   PADS\na.alice  Alice  1\n", b.String())
+	assert.Equal(t, []string{"user-alice"}, created)
+}
+
+func TestAuthorAnonymizeCmd_Confirm(t *testing.T) {
+	var mu sync.Mutex
+	var exports []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(r.URL.Path, "/")
+		if len(parts) > 3 && parts[1] == "p" {
+			mu.Lock()
+			exports = append(exports, parts[2])
+			mu.Unlock()
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch parts[len(parts)-1] {
+		case "listPadsOfAuthor":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"padIDs": ["notes"]}}`))
+		}
+	}))
+	defer ts.Close()
+
+	url := etherpadUrl
+	etherpadUrl = ts.URL
+	defer func() { etherpadUrl = url }()
+	defer func() { authorAnonymizeRemoveChat, authorAnonymizeYes = false, false }()
+
+	cmd := NewAuthorAnonymizeCmd()
+	cmd.SetArgs([]string{"a.alice", "--remove-chat"})
+	cmd.SetIn(bytes.NewBufferString("n\n"))
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+
+	assert.Nil(t, cmd.Execute())
+	assert.Equal(t, "Rewrite 1 pads to remove the chat messages? [y/N] ", b.String())
+	assert.Empty(t, exports)
+
+	cmd = NewAuthorAnonymizeCmd()
+	cmd.SetArgs([]string{"a.alice", "--remove-chat", "--yes"})
+	cmd.SetOut(bytes.NewBufferString(""))
+
+	assert.Nil(t, cmd.Execute())
+	assert.Equal(t, []string{"notes"}, exports)
+}
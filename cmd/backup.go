@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/backup"
+	"github.com/systemli/etherpad-toolkit/pkg/export"
+)
+
+var (
+	backupOutput      string
+	backupConcurrency int
+
+	backupLongDescription = `
+The command backs up all pads with history and chat into a tar.gz archive. Every pad is downloaded via
+/p/<pad>/export/etherpad and stored as pads/<pad>.etherpad. The manifest.json at the end of the archive contains the
+format version, the revisions, the last edit, the size and the SHA-256 checksum of every pad.
+
+The archive is named etherpad-backup-<timestamp>.tar.gz if no output is given.
+
+Example:
+
+etherpad-toolkit backup --output /var/backups/etherpad.tar.gz
+`
+
+	backupCmd = NewBackupCmd()
+)
+
+func init() {
+	rootCmd.AddCommand(backupCmd)
+}
+
+func NewBackupCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Backs up all Pads into an archive",
+		Long:  backupLongDescription,
+		Run: func(cmd *cobra.Command, args []string) {
+			output := backupOutput
+			if output == "" {
+				output = fmt.Sprintf("etherpad-backup-%s.tar.gz", time.Now().Format("20060102-150405"))
+			}
+			if !strings.HasSuffix(output, ".tar.gz") && !strings.HasSuffix(output, ".tgz") {
+				log.WithField("output", output).Error("output must be a .tar.gz or .tgz archive")
+				return
+			}
+
+			etherpad := pkg.NewEtherpadClient(etherpadUrl, etherpadApiKey)
+			pads, err := etherpad.ListAllPads()
+			if err != nil {
+				log.WithError(err).Error("failed to list all pads")
+				return
+			}
+
+			target, err := export.NewArchiveTarget(output)
+			if err != nil {
+				log.WithError(err).Error("failed to create archive")
+				return
+			}
+
+			b := backup.NewBackup(etherpad)
+			b.Concurrency = backupConcurrency
+
+			log.WithFields(log.Fields{"count": len(pads), "output": output}).Info("start backup")
+			manifest, err := b.Create(pads, target)
+			if closeErr := target.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				log.WithError(err).Error("failed to write backup")
+				return
+			}
+
+			log.WithFields(log.Fields{"output": output, "pads": len(manifest.Pads) - manifest.Failed(), "failed": manifest.Failed()}).Info("finished backup")
+		},
+	}
+
+	cmd.Flags().StringVar(&backupOutput, "output", "", "Path of the archive (.tar.gz or .tgz).")
+	cmd.Flags().IntVar(&backupConcurrency, "concurrency", 4, "Concurrency for the backup process")
+
+	return cmd
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/systemli/etherpad-toolkit/pkg/backup"
+)
+
+func TestBackupCmd(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"padIDs": []}}`))
+	}))
+	defer ts.Close()
+
+	url := etherpadUrl
+	etherpadUrl = ts.URL
+	defer func() { etherpadUrl = url }()
+
+	output := filepath.Join(t.TempDir(), "backup.tar.gz")
+	cmd := NewBackupCmd()
+	cmd.SetArgs([]string{"--output", output})
+	err := cmd.Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manifest, err := backup.ReadManifest(output)
+	assert.Nil(t, err)
+	assert.Empty(t, manifest.Pads)
+}
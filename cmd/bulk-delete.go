@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/helper"
+	"github.com/systemli/etherpad-toolkit/pkg/remove"
+)
+
+var (
+	bulkDeleteFile        string
+	bulkDeleteFilter      padFilterFlags
+	bulkDeleteExpiration  string
+	bulkDeleteBackup      string
+	bulkDeleteYes         bool
+	bulkDeleteDryRun      bool
+	bulkDeleteConcurrency int
+
+	bulkDeleteLongDescription = `
+The command removes many pads at once. The pads are given as arguments, read from a file with one pad per line (or
+from stdin with --file -) or selected by the filters. The filters are applied to the given pads as well. With
+--expiration only the pads which are expired by the purge rules are deleted.
+
+The selected pads are listed and have to be confirmed before the deletion, unless --yes is set. --yes is required when
+the pads are read from stdin. With --backup the pads are backed up into a tar.gz archive before the deletion, pads
+which fail to back up are not deleted. The result of every pad is printed at the end.
+
+Example:
+
+etherpad-toolkit bulk-delete --file spam.txt --backup spam.tar.gz
+etherpad-toolkit bulk-delete --regex '^[a-z0-9]{32}$' --expiration "default:24h" --yes
+`
+
+	bulkDeleteCmd = NewBulkDeleteCmd()
+)
+
+func init() {
+	rootCmd.AddCommand(bulkDeleteCmd)
+}
+
+func NewBulkDeleteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bulk-delete [pad...]",
+		Short: "Removes multiple Pads from a list or filter",
+		Long:  bulkDeleteLongDescription,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 0 && bulkDeleteFile == "" && bulkDeleteFilter.empty() && bulkDeleteExpiration == "" {
+				cmd.Print(cmd.UsageString())
+				return
+			}
+			if bulkDeleteFile == "-" && !bulkDeleteYes && !bulkDeleteDryRun {
+				log.Error("--yes is required when the pads are read from stdin")
+				return
+			}
+			if bulkDeleteBackup != "" && !strings.HasSuffix(bulkDeleteBackup, ".tar.gz") && !strings.HasSuffix(bulkDeleteBackup, ".tgz") {
+				log.WithField("backup", bulkDeleteBackup).Error("backup must be a .tar.gz or .tgz archive")
+				return
+			}
+
+			filter, err := bulkDeleteFilter.padFilter()
+			if err != nil {
+				log.WithError(err).Error("failed to parse filter")
+				return
+			}
+
+			etherpad := pkg.NewEtherpadClient(etherpadUrl, etherpadApiKey)
+			deleter := remove.NewDeleter(etherpad, bulkDeleteDryRun)
+			deleter.Backup = bulkDeleteBackup
+			deleter.Concurrency = bulkDeleteConcurrency
+			if bulkDeleteExpiration != "" {
+				deleter.Expiration, err = helper.ParsePadExpiration(bulkDeleteExpiration)
+				if err != nil {
+					log.WithError(err).Error("failed to parse expiration string")
+					return
+				}
+			}
+
+			pads := args
+			if bulkDeleteFile != "" {
+				listed, err := readPadList(cmd, bulkDeleteFile)
+				if err != nil {
+					log.WithError(err).Error("failed to read pads")
+					return
+				}
+				pads = append(pads, listed...)
+			}
+			if len(args) == 0 && bulkDeleteFile == "" {
+				if pads, err = etherpad.ListAllPads(); err != nil {
+					log.WithError(err).Error("failed to list all pads")
+					return
+				}
+			} else if len(pads) == 0 {
+				// an empty list must not select all pads
+				log.Error("no pads given")
+				return
+			}
+
+			selected, results, err := deleter.Select(filter.Apply(pads))
+			if err != nil {
+				log.WithError(err).Error("failed to select pads")
+				return
+			}
+
+			if len(selected) > 0 && !bulkDeleteYes && !bulkDeleteDryRun && !confirmDeletion(cmd, selected) {
+				log.Info("aborted")
+				return
+			}
+
+			deleted, err := deleter.Delete(selected)
+			if err != nil {
+				log.WithError(err).Error("failed to back up pads")
+				return
+			}
+			results = append(results, deleted...)
+
+			if err = remove.WriteResults(cmd.OutOrStdout(), results); err != nil {
+				log.WithError(err).Error("failed to write results")
+			}
+
+			log.WithFields(log.Fields{
+				"deleted": remove.Count(results, remove.StatusDeleted) + remove.Count(results, remove.StatusDryRun),
+				"skipped": remove.Count(results, remove.StatusSkipped) + remove.Count(results, remove.StatusKept) + remove.Count(results, remove.StatusMissing),
+				"failed":  remove.Count(results, remove.StatusFailed),
+			}).Info("finished bulk delete")
+		},
+	}
+
+	cmd.Flags().StringVar(&bulkDeleteFile, "file", "", "File with one pad per line, - for stdin.")
+	bulkDeleteFilter.register(cmd)
+	cmd.Flags().StringVar(&bulkDeleteExpiration, "expiration", "", "Delete only pads which are expired by the purge rules. Example: \"default:720h,temp:24h\"")
+	cmd.Flags().StringVar(&bulkDeleteBackup, "backup", "", "Path of a tar.gz archive to back up the pads before the deletion.")
+	cmd.Flags().BoolVarP(&bulkDeleteYes, "yes", "y", false, "Delete the pads without confirmation.")
+	cmd.Flags().BoolVar(&bulkDeleteDryRun, "dry-run", false, "Enable dry-run")
+	cmd.Flags().IntVar(&bulkDeleteConcurrency, "concurrency", 4, "Concurrency for the delete process")
+
+	return cmd
+}
+
+// readPadList reads the pads from the file or from stdin if the file is "-".
+func readPadList(cmd *cobra.Command, file string) ([]string, error) {
+	var r io.Reader = cmd.InOrStdin()
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	return remove.ReadPads(r)
+}
+
+// confirmDeletion lists the pads and asks for confirmation.
+func confirmDeletion(cmd *cobra.Command, pads []string) bool {
+	return confirmPads(cmd, pads, "deleted")
+}
+
+// confirmPads lists the pads with the action which will be applied to them and asks for confirmation.
+func confirmPads(cmd *cobra.Command, pads []string, action string) bool {
+	out := cmd.OutOrStdout()
+	_, _ = fmt.Fprintf(out, "The following %d pads will be %s:\n", len(pads), action)
+	for _, pad := range pads {
+		_, _ = fmt.Fprintf(out, "  %s\n", pad)
+	}
+
+	return confirm(cmd, "Continue?")
+}
+
+// confirm asks the question on the output of the command and returns true if the input is yes.
+func confirm(cmd *cobra.Command, question string) bool {
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s [y/N] ", question)
+
+	answer, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+
+	return answer == "y" || answer == "yes"
+}
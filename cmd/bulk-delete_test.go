@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBulkDeleteCmd(t *testing.T) {
+	cmd := NewBulkDeleteCmd()
+	cmd.SetArgs([]string{})
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	err := cmd.Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, cmd.UsageString(), string(out))
+}
+
+func TestConfirmDeletion(t *testing.T) {
+	cmd := NewBulkDeleteCmd()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+
+	cmd.SetIn(bytes.NewBufferString("y\n"))
+	assert.True(t, confirmDeletion(cmd, []string{"pad1", "pad2"}))
+	assert.Equal(t, "The following 2 pads will be deleted:\n  pad1\n  pad2\nContinue? [y/N] ", b.String())
+
+	cmd.SetIn(bytes.NewBufferString("\n"))
+	assert.False(t, confirmDeletion(cmd, []string{"pad1"}))
+}
+
+func TestNewBulkDeleteCmd_EmptyFile(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.URL.Path)
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"padIDs": ["pad1", "pad2"]}}`))
+	}))
+	defer ts.Close()
+
+	url := etherpadUrl
+	etherpadUrl = ts.URL
+	defer func() { etherpadUrl = url }()
+
+	file := filepath.Join(t.TempDir(), "pads.txt")
+	if err := os.WriteFile(file, []byte("# no pads\n\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := NewBulkDeleteCmd()
+	cmd.SetArgs([]string{"--file", file, "--yes"})
+	cmd.SetOut(bytes.NewBufferString(""))
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd = NewBulkDeleteCmd()
+	cmd.SetArgs([]string{"--file", "-", "--yes"})
+	cmd.SetIn(bytes.NewBufferString(""))
+	cmd.SetOut(bytes.NewBufferString(""))
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Empty(t, requests)
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/rename"
+)
+
+var (
+	bulkMovePattern     string
+	bulkMoveReplacement string
+	bulkMoveMapping     string
+	bulkMoveForce       bool
+	bulkMoveDryRun      bool
+	bulkMoveYes         bool
+	bulkMoveConcurrency int
+
+	bulkMoveLongDescription = `
+The command renames many pads at once. The renames are defined by a regular expression with a replacement template or
+by a CSV file with the columns source and destination. The replacement supports $1 or ${name} for submatches, a
+literal $ has to be written as $$.
+
+All renames are printed and validated before the execution: the command aborts if a source is missing, two pads are
+renamed to the same destination or the renames contain a cycle. Renames to a pad which is renamed itself are executed
+in the right order. If force is true and the destination pad exists, it will be overwritten. The renames have to be
+confirmed before the execution, unless --yes is set.
+
+Example:
+
+etherpad-toolkit bulk-move --pattern '^(.+)-tmp$' --replacement '${1}-temp' --dry-run
+etherpad-toolkit bulk-move --pattern '^' --replacement 'g.s8oes9dhwrvt0zif$$'
+etherpad-toolkit bulk-move --mapping renames.csv
+`
+
+	bulkMoveCmd = NewBulkMoveCmd()
+)
+
+func init() {
+	rootCmd.AddCommand(bulkMoveCmd)
+}
+
+func NewBulkMoveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bulk-move",
+		Short: "Moves multiple Pads by pattern or mapping",
+		Long:  bulkMoveLongDescription,
+		Run: func(cmd *cobra.Command, args []string) {
+			if (bulkMovePattern == "") == (bulkMoveMapping == "") {
+				cmd.Print(cmd.UsageString())
+				return
+			}
+
+			etherpad := pkg.NewEtherpadClient(etherpadUrl, etherpadApiKey)
+			pads, err := etherpad.ListAllPads()
+			if err != nil {
+				log.WithError(err).Error("failed to fetch pads")
+				return
+			}
+
+			var renames []rename.Rename
+			if bulkMoveMapping != "" {
+				renames, err = rename.LoadMapping(bulkMoveMapping)
+				if err != nil {
+					log.WithError(err).Error("failed to load mapping")
+					return
+				}
+			} else {
+				pattern, err := regexp.Compile(bulkMovePattern)
+				if err != nil {
+					log.WithError(err).Error("failed to parse pattern")
+					return
+				}
+				renames = rename.FromPattern(pads, pattern, bulkMoveReplacement)
+			}
+
+			batches, err := rename.Plan(renames, pads, bulkMoveForce)
+			if err != nil {
+				log.WithError(err).Error("failed to plan renames")
+				return
+			}
+
+			if err = rename.WritePreview(cmd.OutOrStdout(), batches, pads); err != nil {
+				log.WithError(err).Error("failed to write renames")
+				return
+			}
+
+			if len(renames) > 0 && !bulkMoveYes && !bulkMoveDryRun && !confirm(cmd, fmt.Sprintf("Move %d pads?", len(renames))) {
+				log.Info("aborted")
+				return
+			}
+
+			mover := rename.NewMover(etherpad, bulkMoveForce, bulkMoveDryRun)
+			mover.Concurrency = bulkMoveConcurrency
+			failed := mover.Execute(batches)
+
+			log.WithFields(log.Fields{"renames": len(renames), "failed": failed}).Info("finished bulk move")
+		},
+	}
+
+	cmd.Flags().StringVar(&bulkMovePattern, "pattern", "", "Regular expression to select the pads")
+	cmd.Flags().StringVar(&bulkMoveReplacement, "replacement", "", "Replacement template for the new pad name")
+	cmd.Flags().StringVar(&bulkMoveMapping, "mapping", "", "CSV file with the columns source and destination")
+	cmd.Flags().BoolVar(&bulkMoveForce, "force", false, "If set and the destination pad exists, it will be overwritten.")
+	cmd.Flags().BoolVar(&bulkMoveDryRun, "dry-run", false, "Enable dry-run")
+	cmd.Flags().BoolVarP(&bulkMoveYes, "yes", "y", false, "Move the pads without confirmation.")
+	cmd.Flags().IntVar(&bulkMoveConcurrency, "concurrency", 4, "Concurrency for the move process")
+
+	return cmd
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBulkMoveCmd(t *testing.T) {
+	cmd := NewBulkMoveCmd()
+	cmd.SetArgs([]string{})
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	err := cmd.Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, cmd.UsageString(), string(out))
+}
+
+func TestBulkMoveCmd_Confirm(t *testing.T) {
+	var mu sync.Mutex
+	var moves []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(r.URL.Path, "/")
+		switch parts[len(parts)-1] {
+		case "listAllPads":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"padIDs": ["pad-tmp"]}}`))
+		case "movePad":
+			mu.Lock()
+			moves = append(moves, r.FormValue("sourceID")+" -> "+r.FormValue("destinationID"))
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": null}`))
+		}
+	}))
+	defer ts.Close()
+
+	url := etherpadUrl
+	etherpadUrl = ts.URL
+	defer func() { etherpadUrl = url }()
+	defer func() { bulkMovePattern, bulkMoveReplacement, bulkMoveYes = "", "", false }()
+
+	cmd := NewBulkMoveCmd()
+	cmd.SetArgs([]string{"--pattern", "-tmp$", "--replacement", "-temp"})
+	cmd.SetIn(bytes.NewBufferString("n\n"))
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+
+	assert.Nil(t, cmd.Execute())
+	assert.Contains(t, b.String(), "Move 1 pads? [y/N] ")
+	assert.Empty(t, moves)
+
+	cmd = NewBulkMoveCmd()
+	cmd.SetArgs([]string{"--pattern", "-tmp$", "--replacement", "-temp", "--yes"})
+	cmd.SetOut(bytes.NewBufferString(""))
+
+	assert.Nil(t, cmd.Execute())
+	assert.Equal(t, []string{"pad-tmp -> pad-temp"}, moves)
+}
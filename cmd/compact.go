@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/compact"
+)
+
+var (
+	compactThreshold   int
+	compactSuffixes    []string
+	compactKeepChat    bool
+	compactBackup      bool
+	compactDryRun      bool
+	compactConcurrency int
+
+	compactLongDescription = `
+The command removes the revision history of pads which exceed the revision threshold. The pad is replaced by a copy 
+without history, the pad ID and the current text are preserved. If no pads are given, all pads are checked.
+
+The chat is removed as well, unless --keep-chat is set. With --backup a full copy of the pad is created with the 
+ID "<pad>-<unix timestamp>-backup" before the history is removed. The copies expire with the default rule of purge, 
+unless a rule for the suffix "backup" is added, e.g. "backup:2160h".
+
+Changes which are made to a pad during the compaction may get lost.
+
+Example:
+
+etherpad-toolkit compact --threshold 10000 --suffixes keep --keep-chat
+`
+
+	compactCmd = NewCompactCmd()
+)
+
+func init() {
+	rootCmd.AddCommand(compactCmd)
+}
+
+func NewCompactCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compact [pad...]",
+		Short: "Removes the revision history of large Pads",
+		Long:  compactLongDescription,
+		Run: func(cmd *cobra.Command, args []string) {
+			etherpad := pkg.NewEtherpadClient(etherpadUrl, etherpadApiKey)
+			pads := args
+			if len(pads) == 0 {
+				var err error
+				pads, err = etherpad.ListAllPads()
+				if err != nil {
+					log.WithError(err).Error("failed to list all pads")
+					return
+				}
+			}
+
+			compactor := compact.NewCompactor(etherpad, compactThreshold, compactDryRun)
+			compactor.Suffixes = compactSuffixes
+			compactor.KeepChat = compactKeepChat
+			compactor.Backup = compactBackup
+			compactor.CompactPads(pads, compactConcurrency)
+		},
+	}
+
+	cmd.Flags().IntVar(&compactThreshold, "threshold", 10000, "Compact pads with more revisions than the threshold.")
+	cmd.Flags().StringSliceVar(&compactSuffixes, "suffixes", []string{}, "Suffixes of pads to compact. All pads if empty.")
+	cmd.Flags().BoolVar(&compactKeepChat, "keep-chat", false, "Keep the chat messages.")
+	cmd.Flags().BoolVar(&compactBackup, "backup", false, "Create a backup copy \"<pad>-<unix timestamp>-backup\" before compacting a pad.")
+	cmd.Flags().BoolVar(&compactDryRun, "dry-run", false, "Enable dry-run")
+	cmd.Flags().IntVar(&compactConcurrency, "concurrency", 4, "Concurrency for the compaction process")
+
+	return cmd
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/diff"
+)
+
+var (
+	diffContext int
+	diffHTML    bool
+
+	diffLongDescription = `
+The command shows the changes of a pad between two revisions as unified diff of the text. With --html the diff is
+created by Etherpad (createDiffHTML) and printed as HTML with the authors of the changes.
+
+Example:
+
+etherpad-toolkit diff pad-keep 120 125
+`
+
+	diffCmd = NewDiffCmd()
+)
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+}
+
+func NewDiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff [pad] [revA] [revB]",
+		Short: "Shows the changes of a Pad between two revisions",
+		Long:  diffLongDescription,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				cmd.Print(cmd.UsageString())
+				return
+			}
+
+			pad := args[0]
+			revA, err := strconv.Atoi(args[1])
+			if err != nil {
+				log.WithError(err).Error("failed to parse revision")
+				return
+			}
+			revB, err := strconv.Atoi(args[2])
+			if err != nil {
+				log.WithError(err).Error("failed to parse revision")
+				return
+			}
+
+			etherpad := pkg.NewEtherpadClient(etherpadUrl, etherpadApiKey)
+			if diffHTML {
+				html, authors, err := etherpad.CreateDiffHTML(pad, revA, revB)
+				if err != nil {
+					log.WithError(err).WithField("pad", pad).Error("failed to create diff")
+					return
+				}
+				log.WithField("authors", authors).Info("authors of the changes")
+				cmd.Println(html)
+				return
+			}
+
+			textA, err := etherpad.GetTextAtRevision(pad, revA)
+			if err != nil {
+				log.WithError(err).WithFields(log.Fields{"pad": pad, "revision": revA}).Error("failed to get text")
+				return
+			}
+			textB, err := etherpad.GetTextAtRevision(pad, revB)
+			if err != nil {
+				log.WithError(err).WithFields(log.Fields{"pad": pad, "revision": revB}).Error("failed to get text")
+				return
+			}
+
+			cmd.Print(diff.Unified(textA, textB, fmt.Sprintf("%s@%d", pad, revA), fmt.Sprintf("%s@%d", pad, revB), diffContext))
+		},
+	}
+
+	cmd.Flags().IntVar(&diffContext, "context", 3, "Number of unchanged lines around the changes.")
+	cmd.Flags().BoolVar(&diffHTML, "html", false, "Print the diff as HTML created by Etherpad.")
+
+	return cmd
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDiffCmd(t *testing.T) {
+	cmd := NewDiffCmd()
+	cmd.SetArgs([]string{})
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	err := cmd.Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, cmd.UsageString(), string(out))
+}
+
+func TestDiffCmd_Text(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		text := "Hello\nWorld\n"
+		if r.URL.Query().Get("rev") == "2" {
+			text = "Hello\nEtherpad\n"
+		}
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"text": "` + strings.ReplaceAll(text, "\n", `\n`) + `"}}`))
+	}))
+	defer ts.Close()
+
+	url := etherpadUrl
+	etherpadUrl = ts.URL
+	defer func() { etherpadUrl = url }()
+
+	cmd := NewDiffCmd()
+	cmd.SetArgs([]string{"pad", "1", "2"})
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	assert.Nil(t, cmd.Execute())
+
+	assert.Equal(t, "--- pad@1\n+++ pad@2\n@@ -1,2 +1,2 @@\n Hello\n-World\n+Etherpad\n", b.String())
+}
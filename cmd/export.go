@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/export"
+)
+
+var (
+	exportFilter      padFilterFlags
+	exportFormat      string
+	exportOutputDir   string
+	exportArchive     string
+	exportRevision    int
+	exportConcurrency int
+
+	exportLongDescription = `
+The command exports pads into a directory or an archive. If no pads are given, all pads which match the filter are
+exported. The formats txt and html are fetched via the API, markdown is converted from the HTML and etherpad is the
+full pad with history and chat as downloaded via /p/<pad>/export/etherpad.
+
+A manifest.json is written next to the files and lists the exported pads with size and SHA-256 checksum as well as
+the pads which failed to export. The archive type is chosen by the extension: .zip, .tar.gz or .tgz.
+
+Example:
+
+etherpad-toolkit export --suffix keep --format markdown --archive pads.tar.gz
+`
+
+	exportCmd = NewExportCmd()
+)
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+}
+
+func NewExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [pad...]",
+		Short: "Exports Pads into files",
+		Long:  exportLongDescription,
+		Run: func(cmd *cobra.Command, args []string) {
+			etherpad := pkg.NewEtherpadClient(etherpadUrl, etherpadApiKey)
+			exporter, err := export.NewExporter(etherpad, exportFormat)
+			if err != nil {
+				log.WithError(err).Error("failed to configure export")
+				return
+			}
+			exporter.Revision = exportRevision
+			exporter.Concurrency = exportConcurrency
+
+			pads, err := selectPads(etherpad, args, exportFilter)
+			if err != nil {
+				log.WithError(err).Error("failed to select pads")
+				return
+			}
+
+			var target export.Target
+			if exportArchive != "" {
+				target, err = export.NewArchiveTarget(exportArchive)
+			} else {
+				target, err = export.NewDirTarget(exportOutputDir)
+			}
+			if err != nil {
+				log.WithError(err).Error("failed to create export target")
+				return
+			}
+
+			manifest, err := exporter.Export(pads, target)
+			if closeErr := target.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				log.WithError(err).Error("failed to write export")
+				return
+			}
+
+			log.WithFields(log.Fields{"exported": len(manifest.Pads) - manifest.Failed(), "failed": manifest.Failed()}).Info("finished export")
+		},
+	}
+
+	exportFilter.register(cmd)
+	cmd.Flags().StringVar(&exportFormat, "format", export.FormatText, "Export format: "+strings.Join(export.Formats, ", "))
+	cmd.Flags().StringVar(&exportOutputDir, "output-dir", "export", "Directory to write the exported files.")
+	cmd.Flags().StringVar(&exportArchive, "archive", "", "Archive to write the exported files instead of the directory (.zip, .tar.gz or .tgz).")
+	cmd.Flags().IntVar(&exportRevision, "revision", pkg.LatestRevision, "Revision to export. The latest revision if -1.")
+	cmd.Flags().IntVar(&exportConcurrency, "concurrency", 4, "Concurrency for the export process")
+
+	return cmd
+}
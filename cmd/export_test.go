@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportCmd(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"text": "Hello"}}`))
+	}))
+	defer ts.Close()
+
+	url := etherpadUrl
+	etherpadUrl = ts.URL
+	defer func() { etherpadUrl = url }()
+
+	dir := t.TempDir()
+	cmd := NewExportCmd()
+	cmd.SetArgs([]string{"--output-dir", dir, "pad1"})
+	err := cmd.Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, "pad1.txt"))
+	assert.Nil(t, err)
+	assert.Equal(t, "Hello", string(b))
+	assert.FileExists(t, filepath.Join(dir, "manifest.json"))
+}
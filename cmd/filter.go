@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/helper"
+)
+
+// padFilterFlags are the flags to select pads by their ID.
+type padFilterFlags struct {
+	prefixes []string
+	suffixes []string
+	regex    string
+	group    string
+}
+
+func (f *padFilterFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&f.prefixes, "prefix", []string{}, "Select pads which start with one of the prefixes.")
+	cmd.Flags().StringSliceVar(&f.suffixes, "suffix", []string{}, "Select pads which end with one of the suffixes, e.g. keep for \"pad-keep\".")
+	cmd.Flags().StringVar(&f.regex, "regex", "", "Select pads which match the regular expression.")
+	cmd.Flags().StringVar(&f.group, "group", "", "Select pads of the group, e.g. g.s8oes9dhwrvt0zif.")
+}
+
+func (f *padFilterFlags) padFilter() (*helper.PadFilter, error) {
+	return helper.NewPadFilter(f.prefixes, f.suffixes, f.regex, f.group)
+}
+
+// selectPads returns the given pads or all pads which match the filter if no pads are given.
+func selectPads(etherpad *pkg.Etherpad, args []string, flags padFilterFlags) ([]string, error) {
+	if len(args) > 0 {
+		return args, nil
+	}
+
+	filter, err := flags.padFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	pads, err := etherpad.ListAllPads()
+	if err != nil {
+		return nil, err
+	}
+
+	return filter.Apply(pads), nil
+}
+
+// empty returns true if no filter is set.
+func (f *padFilterFlags) empty() bool {
+	return len(f.prefixes) == 0 && len(f.suffixes) == 0 && f.regex == "" && f.group == ""
+}
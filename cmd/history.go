@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/history"
+)
+
+var (
+	historyAuthor string
+	historySince  time.Duration
+	historyLimit  int
+	historyOutput string
+
+	historyLongDescription = `
+The command lists the revisions of a pad with timestamp, author and the number of added and removed characters. The
+pad is downloaded in the .etherpad format, so the export endpoint must be reachable for the toolkit.
+
+Example:
+
+etherpad-toolkit history pad-keep --since 24h
+etherpad-toolkit history pad-keep --author a.7LWpbTtQCiTTGXR3 --output json
+`
+
+	historyCmd = NewHistoryCmd()
+)
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+}
+
+func NewHistoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history [pad]",
+		Short: "Lists the revisions of a Pad",
+		Long:  historyLongDescription,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				cmd.Print(cmd.UsageString())
+				return
+			}
+
+			revisions, err := history.Load(pkg.NewEtherpadClient(etherpadUrl, etherpadApiKey), args[0])
+			if err != nil {
+				log.WithError(err).WithField("pad", args[0]).Error("failed to load history")
+				return
+			}
+
+			var selected []history.Revision
+			for _, rev := range revisions {
+				if historyAuthor != "" && rev.Author != historyAuthor && rev.AuthorName != historyAuthor {
+					continue
+				}
+				if historySince > 0 && rev.Timestamp.Before(time.Now().Add(-historySince)) {
+					continue
+				}
+				selected = append(selected, rev)
+			}
+			if historyLimit > 0 && len(selected) > historyLimit {
+				selected = selected[len(selected)-historyLimit:]
+			}
+
+			if err = history.Write(cmd.OutOrStdout(), historyOutput, selected); err != nil {
+				log.WithError(err).Error("failed to write history")
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&historyAuthor, "author", "", "List only revisions of the author, by ID or name.")
+	cmd.Flags().DurationVar(&historySince, "since", 0, "List only revisions within the duration, e.g. 24h.")
+	cmd.Flags().IntVar(&historyLimit, "limit", 0, "Maximum number of the latest revisions. All revisions if 0.")
+	cmd.Flags().StringVarP(&historyOutput, "output", "o", history.FormatTable, "Output format: table, json")
+
+	return cmd
+}
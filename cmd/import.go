@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/importer"
+)
+
+var (
+	importMapping string
+	importForce   bool
+	importDryRun  bool
+
+	importLongDescription = `
+The command creates pads from .txt, .html, .md and .etherpad files. The path is a single file or a directory which is
+searched recursively. The pad ID is the file name without extension, escaped characters as written by export are
+unescaped. Alternatively a CSV mapping file with the columns file and pad defines the pad IDs.
+
+Text and Markdown are imported as text, HTML with setHTML and .etherpad files with history and chat via the import
+endpoint of Etherpad. If force is true and the pad exists, it will be overwritten.
+
+Example:
+
+etherpad-toolkit import export/ --force
+etherpad-toolkit import --mapping mapping.csv
+`
+
+	importCmd = NewImportCmd()
+)
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+}
+
+func NewImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import [path]",
+		Short: "Imports Pads from files",
+		Long:  importLongDescription,
+		Run: func(cmd *cobra.Command, args []string) {
+			if (len(args) != 1) == (importMapping == "") {
+				cmd.Print(cmd.UsageString())
+				return
+			}
+
+			var sources []importer.Source
+			var err error
+			if importMapping != "" {
+				sources, err = importer.LoadMapping(importMapping)
+			} else {
+				sources, err = importer.Collect(args[0])
+			}
+			if err != nil {
+				log.WithError(err).Error("failed to collect files")
+				return
+			}
+
+			imp := importer.NewImporter(pkg.NewEtherpadClient(etherpadUrl, etherpadApiKey), importForce, importDryRun)
+			failed, err := imp.ImportAll(sources)
+			if err != nil {
+				log.WithError(err).Error("failed to import pads")
+				return
+			}
+
+			log.WithFields(log.Fields{"imported": len(sources) - failed, "failed": failed}).Info("finished import")
+		},
+	}
+
+	cmd.Flags().StringVar(&importMapping, "mapping", "", "CSV file with the columns file and pad instead of a path.")
+	cmd.Flags().BoolVar(&importForce, "force", false, "If set and the pad exists, it will be overwritten.")
+	cmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Enable dry-run")
+
+	return cmd
+}
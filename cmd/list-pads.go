@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/list"
+)
+
+var (
+	listFilter      padFilterFlags
+	listFields      []string
+	listSort        string
+	listReverse     bool
+	listLimit       int
+	listOutput      string
+	listConcurrency int
+
+	listPadsLongDescription = `
+The command lists the pads of Etherpad. The pads can be filtered by prefix, suffix, regular expression and group.
+Additional fields are fetched with --fields, the available fields are last-edited, revisions, users and authors.
+The output format is table, json, ndjson or csv.
+
+Example:
+
+etherpad-toolkit list-pads --suffix keep --fields last-edited,revisions --sort revisions --reverse --limit 10
+`
+
+	listPadsCmd = NewListPadsCmd()
+)
+
+func init() {
+	rootCmd.AddCommand(listPadsCmd)
+}
+
+func NewListPadsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list-pads",
+		Short: "Lists Pads",
+		Long:  listPadsLongDescription,
+		Run: func(cmd *cobra.Command, args []string) {
+			filter, err := listFilter.padFilter()
+			if err != nil {
+				log.WithError(err).Error("failed to parse filter")
+				return
+			}
+
+			lister := list.NewLister(pkg.NewEtherpadClient(etherpadUrl, etherpadApiKey))
+			lister.Filter = filter
+			lister.Fields = listFields
+			lister.SortBy = listSort
+			lister.Reverse = listReverse
+			lister.Limit = listLimit
+			lister.Concurrency = listConcurrency
+
+			pads, err := lister.List()
+			if err != nil {
+				log.WithError(err).Error("failed to list pads")
+				return
+			}
+
+			if err = list.Write(cmd.OutOrStdout(), listOutput, pads, listFields); err != nil {
+				log.WithError(err).Error("failed to write pads")
+			}
+		},
+	}
+
+	listFilter.register(cmd)
+	cmd.Flags().StringSliceVar(&listFields, "fields", []string{}, "Fields to fetch for every pad: "+strings.Join(list.Fields, ", "))
+	cmd.Flags().StringVar(&listSort, "sort", list.FieldID, "Field to sort the pads by.")
+	cmd.Flags().BoolVar(&listReverse, "reverse", false, "Sort the pads in descending order.")
+	cmd.Flags().IntVar(&listLimit, "limit", 0, "Maximum number of pads to list. All pads if 0.")
+	cmd.Flags().StringVarP(&listOutput, "output", "o", list.FormatTable, "Output format: table, json, ndjson or csv")
+	cmd.Flags().IntVar(&listConcurrency, "concurrency", 4, "Concurrency for fetching the fields")
+
+	return cmd
+}
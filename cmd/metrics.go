@@ -3,18 +3,26 @@ package cmd
 import (
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/helper"
 	"github.com/systemli/etherpad-toolkit/pkg/metrics"
 )
 
 var (
-	listenAddr string
-	suffixes   string
+	listenAddr         string
+	suffixes           string
+	metricsExpiration  string
+	metricsConcurrency int
+	refreshInterval    time.Duration
+	metricsUsers       bool
+	metricsTopPads     int
+	metricsConfigFile  string
+	webConfigFile      string
 
 	metricsCmd = NewMetricsCmd()
 )
@@ -27,18 +35,90 @@ func NewMetricsCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "metrics",
 		Short: "Serves Pad related metrics",
-		Long:  "The Command serves the count of pads grouped by suffix in Prometheus format.",
+		Long:  "The Command serves the count of pads grouped by suffix in Prometheus format. If an expiration is configured, histograms for the age and revisions of pads and the count of expired pads are served per suffix group. With --users the connected users are served per suffix group. The data is collected in the background and served from a cache. With a config file, multiple Etherpad instances are served via /probe?target=<name>. Health, readiness and info are served on /healthz, /readyz and /info. With --web.config.file, basic auth applies to all endpoints, so health checks must send the credentials.",
 		Run: func(cmd *cobra.Command, args []string) {
-			etherpad := pkg.NewEtherpadClient(etherpadUrl, etherpadApiKey)
-			prometheus.MustRegister(metrics.NewPadCollector(etherpad, strings.Split(suffixes, ",")))
+			var instances []metrics.Instance
+			var probe *metrics.ProbeHandler
 
-			http.Handle("/metrics", promhttp.Handler())
-			log.Fatal(http.ListenAndServe(listenAddr, nil))
+			if metricsConfigFile != "" {
+				config, err := metrics.LoadProbeConfig(metricsConfigFile)
+				if err != nil {
+					log.WithError(err).Error("failed to load config file")
+					return
+				}
+				probe, err = metrics.NewProbeHandler(config, strings.Split(suffixes, ","), metricsConcurrency)
+				if err != nil {
+					log.WithError(err).Error("failed to configure targets")
+					return
+				}
+				if refreshInterval > 0 {
+					probe.Run(cmd.Context(), refreshInterval)
+				}
+				for _, target := range config.Targets {
+					instances = append(instances, metrics.Instance{
+						Name:     target.Name,
+						Etherpad: pkg.NewEtherpadClient(target.URL, target.APIKey),
+						Suffixes: target.SuffixesOr(strings.Split(suffixes, ",")),
+					})
+				}
+			} else {
+				etherpad := pkg.NewEtherpadClient(etherpadUrl, etherpadApiKey)
+				collector, err := newPadCollector(etherpad)
+				if err != nil {
+					log.WithError(err).Error("failed to configure collector")
+					return
+				}
+				prometheus.MustRegister(collector)
+				if refreshInterval > 0 {
+					go collector.Run(cmd.Context(), refreshInterval)
+				}
+				// the readiness and info checks use their own client, so their errors are not counted by the collector
+				instances = append(instances, metrics.Instance{
+					Name:     "default",
+					Etherpad: pkg.NewEtherpadClient(etherpadUrl, etherpadApiKey),
+					Suffixes: strings.Split(suffixes, ","),
+				})
+			}
+
+			handler := metrics.NewServer(version, instances)
+			if probe != nil {
+				handler.Handle("/probe", probe)
+			}
+
+			server := &http.Server{
+				Addr:              listenAddr,
+				Handler:           handler,
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+			log.Fatal(metrics.ListenAndServe(server, webConfigFile))
 		},
 	}
 
 	cmd.Flags().StringVar(&listenAddr, "listen.addr", ":9012", "Address on which to expose metrics.")
 	cmd.Flags().StringVar(&suffixes, "suffixes", "keep,temp", "Suffixes to group the pads.")
+	cmd.Flags().StringVar(&metricsExpiration, "expiration", "", "Configuration for pad expiration duration as in purge. Enables the metrics for age, revisions and expired pads.")
+	cmd.Flags().IntVar(&metricsConcurrency, "concurrency", 4, "Concurrency for the API calls per pad")
+	cmd.Flags().BoolVar(&metricsUsers, "users", false, "Enables the metrics for connected users per suffix.")
+	cmd.Flags().IntVar(&metricsTopPads, "users.top", 0, "Number of pads with the most connected users to expose with the pad name.")
+	cmd.Flags().StringVar(&metricsConfigFile, "config.file", "", "YAML file with Etherpad instances to serve via /probe?target=<name>.")
+	cmd.Flags().StringVar(&webConfigFile, "web.config.file", "", "Path to the web config file of the Prometheus exporter-toolkit to enable TLS and basic auth. Basic auth applies to the health endpoints as well.")
+	cmd.Flags().DurationVar(&refreshInterval, "refresh.interval", time.Minute, "Interval to collect the data in the background. Collects on every scrape if 0.")
 
 	return cmd
 }
+
+func newPadCollector(etherpad *pkg.Etherpad) (*metrics.PadCollector, error) {
+	collector := metrics.NewPadCollector(etherpad, strings.Split(suffixes, ","))
+	collector.Concurrency = metricsConcurrency
+	collector.Users = metricsUsers
+	collector.TopPads = metricsTopPads
+	if metricsExpiration != "" {
+		exp, err := helper.ParsePadExpiration(metricsExpiration)
+		if err != nil {
+			return nil, err
+		}
+		collector.Expiration = exp
+	}
+
+	return collector, nil
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/helper"
+	"github.com/systemli/etherpad-toolkit/pkg/info"
+)
+
+var (
+	padInfoExpiration      string
+	padInfoDefaultTextFile string
+	padInfoOutput          string
+
+	padInfoLongDescription = `
+The command prints the metadata of a single pad: revisions, last edit, saved revisions, authors with names, connected
+users, read-only ID, public status and password state of group pads, number of chat messages and the text size.
+
+With --expiration the retention rule and the expiry date are printed as purge would apply them.
+
+Example:
+
+etherpad-toolkit pad-info pad-keep --expiration "default:720h,temp:24h,keep:8760h"
+`
+
+	padInfoCmd = NewPadInfoCmd()
+)
+
+func init() {
+	rootCmd.AddCommand(padInfoCmd)
+}
+
+func NewPadInfoCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pad-info [pad]",
+		Short: "Shows the metadata of a single Pad",
+		Long:  padInfoLongDescription,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				cmd.Print(cmd.UsageString())
+				return
+			}
+
+			inspector := info.NewInspector(pkg.NewEtherpadClient(etherpadUrl, etherpadApiKey))
+			if padInfoExpiration != "" {
+				exp, err := helper.ParsePadExpiration(padInfoExpiration)
+				if err != nil {
+					log.WithError(err).Error("failed to parse expiration string")
+					return
+				}
+				inspector.Expiration = exp
+			}
+			if padInfoDefaultTextFile != "" {
+				text, err := os.ReadFile(padInfoDefaultTextFile)
+				if err != nil {
+					log.WithError(err).Error("failed to read default pad text")
+					return
+				}
+				inspector.DefaultPadText = string(text)
+			}
+
+			pad := args[0]
+			padInfo, err := inspector.Inspect(pad)
+			if err != nil {
+				log.WithError(err).WithField("pad", pad).Error("failed to get pad info")
+				return
+			}
+
+			if err = info.Write(cmd.OutOrStdout(), padInfoOutput, padInfo); err != nil {
+				log.WithError(err).Error("failed to write pad info")
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&padInfoExpiration, "expiration", "", "Configuration for pad expiration duration as in purge. Enables the retention rule and expiry date.")
+	cmd.Flags().StringVar(&padInfoDefaultTextFile, "default-text-file", "", "File with the default pad text of Etherpad. Pads with this text are treated as empty.")
+	cmd.Flags().StringVarP(&padInfoOutput, "output", "o", info.FormatText, "Output format: text or json")
+
+	return cmd
+}
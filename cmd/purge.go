@@ -1,17 +1,48 @@
 package cmd
 
 import (
+	"os"
+	"time"
+
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/chat"
+	"github.com/systemli/etherpad-toolkit/pkg/compact"
 	"github.com/systemli/etherpad-toolkit/pkg/helper"
+	"github.com/systemli/etherpad-toolkit/pkg/metrics"
+	"github.com/systemli/etherpad-toolkit/pkg/notify"
 	"github.com/systemli/etherpad-toolkit/pkg/purge"
 )
 
 var (
-	concurrency int
-	dryRun      bool
-	expiration  string
+	concurrency     int
+	dryRun          bool
+	expiration      string
+	defaultTextFile string
+
+	purgeCompactThreshold int
+	purgeCompactSuffixes  []string
+	purgeCompactKeepChat  bool
+	purgeCompactBackup    bool
+
+	purgeChatRetention time.Duration
+	purgeChatExportDir string
+	purgeChatClear     bool
+
+	notifyWebhooks     []string
+	notifyMatrix       []string
+	notifySlack        []string
+	notifyTemplate     string
+	notifySmtpAddr     string
+	notifySmtpFrom     string
+	notifySmtpTo       []string
+	notifySmtpUsername string
+	notifySmtpPassword string
+
+	purgeMetricsPushgateway string
+	purgeMetricsJob         string
+	purgeMetricsTextfile    string
 
 	longDescription = `
 The command checks every Pad for it’s last edited date. If it is older than the defined limit, the pad will be deleted.
@@ -28,6 +59,37 @@ etherpad-toolkit purge --expiration "default:720h,temp:24h,keep:8760h"
 This configuration will group the pads in three clusters: default (expiration: 30 days, suffix is required!), 
 temp (expiration: 24 hours), keep (expiration: 365 days). If pads in the clusters older than the given expiration the 
 pads will be deleted.
+
+Pads which contain only whitespace or the default pad text can be removed earlier with the reserved key ":empty". The
+key starts with a colon, so pads with the suffix "empty" keep their own rule:
+
+etherpad-toolkit purge --expiration "default:720h,:empty:24h" --default-text-file welcome.txt
+
+This configuration fetches the text of every pad which was not edited for 24 hours and deletes it if there is no 
+meaningful content.
+
+Pads which are kept but exceed a revision threshold can be compacted (see the compact command):
+
+etherpad-toolkit purge --expiration "default:720h,keep:8760h" --compact.threshold 10000 --compact.suffixes keep
+
+Chat messages can be expired independently of the pad text. Etherpad can not delete single chat messages, so pads 
+with chat messages older than the retention are recreated and only the newer messages are restored (or none with 
+--chat.clear). The whole revision history of these pads is removed, only the current text is kept. The chat history 
+can be exported to a directory before:
+
+etherpad-toolkit purge --expiration "default:720h" --chat.retention 168h --chat.export-dir /var/backups/chat
+
+After each run a summary can be sent to webhooks (generic JSON, Matrix or Slack-compatible payloads) and via mail. 
+The message body can be customized with a Go template file which receives the summary:
+
+etherpad-toolkit purge --expiration "default:720h" --notify.slack https://hooks.slack.com/services/... \
+  --notify.smtp.addr mail.example.org:587 --notify.smtp.from etherpad@example.org --notify.smtp.to admin@example.org
+
+Metrics of the run (pads inspected, deleted and failed per suffix, duration, timestamp of the last successful run) can 
+be sent to a Prometheus Pushgateway or written to a file for the node_exporter textfile collector. A dry run 
+does not update the success and the timestamp of the last successful run:
+
+etherpad-toolkit purge --expiration "default:720h" --metrics.textfile /var/lib/node_exporter/etherpad_purge.prom
 `
 
 	purgeCmd = NewPurgeCmd()
@@ -50,13 +112,113 @@ func NewPurgeCmd() *cobra.Command {
 				return
 			}
 			purger := purge.NewPurger(etherpad, exp, dryRun)
-			purger.PurgePads(concurrency)
+			if defaultTextFile != "" {
+				text, err := os.ReadFile(defaultTextFile)
+				if err != nil {
+					log.WithError(err).Error("failed to read default pad text")
+					return
+				}
+				purger.DefaultPadText = string(text)
+			}
+			if purgeChatRetention > 0 {
+				retention := chat.NewRetention(etherpad, purgeChatRetention, dryRun)
+				retention.ExportDir = purgeChatExportDir
+				retention.Clear = purgeChatClear
+				purger.ChatRetention = retention
+			}
+			if purgeCompactThreshold > 0 {
+				compactor := compact.NewCompactor(etherpad, purgeCompactThreshold, dryRun)
+				compactor.Suffixes = purgeCompactSuffixes
+				compactor.KeepChat = purgeCompactKeepChat
+				compactor.Backup = purgeCompactBackup
+				purger.Compactor = compactor
+			}
+			notifiers, err := buildNotifiers()
+			if err != nil {
+				log.WithError(err).Error("failed to configure notifications")
+				return
+			}
+
+			summary := purger.PurgePads(concurrency)
+			if purgeMetricsPushgateway != "" {
+				if err := metrics.PushPurgeMetrics(purgeMetricsPushgateway, purgeMetricsJob, summary); err != nil {
+					log.WithError(err).Error("failed to push metrics")
+				}
+			}
+			if purgeMetricsTextfile != "" {
+				if err := metrics.WritePurgeMetrics(purgeMetricsTextfile, summary); err != nil {
+					log.WithError(err).Error("failed to write metrics")
+				}
+			}
+			for _, notifier := range notifiers {
+				if err := notifier.Notify(summary); err != nil {
+					log.WithError(err).Error("failed to send notification")
+				}
+			}
 		},
 	}
 
 	cmd.Flags().StringVar(&expiration, "expiration", "", "Configuration for pad expiration duration. Example: \"default:720h,temp:24h,keep:8760h\"")
 	cmd.Flags().IntVar(&concurrency, "concurrency", 4, "Concurrency for the purge process")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Enable dry-run")
+	cmd.Flags().StringVar(&defaultTextFile, "default-text-file", "", "File with the default pad text of Etherpad. Pads with this text are treated as empty.")
+	cmd.Flags().DurationVar(&purgeChatRetention, "chat.retention", 0, "Remove chat messages older than the retention. Affected pads lose their whole revision history. Disabled if 0.")
+	cmd.Flags().StringVar(&purgeChatExportDir, "chat.export-dir", "", "Directory to export the chat history before messages are removed.")
+	cmd.Flags().BoolVar(&purgeChatClear, "chat.clear", false, "Remove all chat messages if at least one message is older than the retention.")
+	cmd.Flags().IntVar(&purgeCompactThreshold, "compact.threshold", 0, "Compact remaining pads with more revisions than the threshold. Disabled if 0.")
+	cmd.Flags().StringSliceVar(&purgeCompactSuffixes, "compact.suffixes", []string{}, "Suffixes of pads to compact. All pads if empty.")
+	cmd.Flags().BoolVar(&purgeCompactKeepChat, "compact.keep-chat", false, "Keep the chat messages of compacted pads.")
+	cmd.Flags().BoolVar(&purgeCompactBackup, "compact.backup", false, "Create a backup copy \"<pad>-<unix timestamp>-backup\" before compacting a pad.")
+
+	cmd.Flags().StringSliceVar(&notifyWebhooks, "notify.webhook", []string{}, "URLs to post the summary as JSON.")
+	cmd.Flags().StringSliceVar(&notifyMatrix, "notify.matrix", []string{}, "URLs of Matrix webhooks to post the summary.")
+	cmd.Flags().StringSliceVar(&notifySlack, "notify.slack", []string{}, "URLs of Slack-compatible webhooks to post the summary.")
+	cmd.Flags().StringVar(&notifyTemplate, "notify.template", "", "Go template file for the message body.")
+	cmd.Flags().StringVar(&notifySmtpAddr, "notify.smtp.addr", "", "Address of the SMTP server (host:port) to send the summary.")
+	cmd.Flags().StringVar(&notifySmtpFrom, "notify.smtp.from", "", "Sender address for the summary mail.")
+	cmd.Flags().StringSliceVar(&notifySmtpTo, "notify.smtp.to", []string{}, "Recipients for the summary mail.")
+	cmd.Flags().StringVar(&notifySmtpUsername, "notify.smtp.username", "", "Username for the SMTP server.")
+	cmd.Flags().StringVar(&notifySmtpPassword, "notify.smtp.password", "", "Password for the SMTP server (Env: NOTIFY_SMTP_PASSWORD)")
+	cmd.Flags().StringVar(&purgeMetricsPushgateway, "metrics.pushgateway", "", "URL of the Prometheus Pushgateway to push the run metrics.")
+	cmd.Flags().StringVar(&purgeMetricsJob, "metrics.job", "etherpad_toolkit_purge", "Job name for the Prometheus Pushgateway.")
+	cmd.Flags().StringVar(&purgeMetricsTextfile, "metrics.textfile", "", "File to write the run metrics for the node_exporter textfile collector.")
+
+	if os.Getenv("NOTIFY_SMTP_PASSWORD") != "" {
+		notifySmtpPassword = os.Getenv("NOTIFY_SMTP_PASSWORD")
+	}
 
 	return cmd
 }
+
+func buildNotifiers() ([]notify.Notifier, error) {
+	var notifiers []notify.Notifier
+
+	tmpl, err := notify.ParseTemplate(notifyTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks := map[string][]string{
+		notify.FormatGeneric: notifyWebhooks,
+		notify.FormatMatrix:  notifyMatrix,
+		notify.FormatSlack:   notifySlack,
+	}
+	for format, urls := range webhooks {
+		for _, url := range urls {
+			webhook, err := notify.NewWebhook(url, format, tmpl)
+			if err != nil {
+				return nil, err
+			}
+			notifiers = append(notifiers, webhook)
+		}
+	}
+
+	if notifySmtpAddr != "" {
+		mail := notify.NewMail(notifySmtpAddr, notifySmtpFrom, notifySmtpTo, tmpl)
+		mail.Username = notifySmtpUsername
+		mail.Password = notifySmtpPassword
+		notifiers = append(notifiers, mail)
+	}
+
+	return notifiers, nil
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/revert"
+)
+
+var (
+	restoreRevisionAuthor      string
+	restoreRevisionAfter       string
+	restoreRevisionFilter      padFilterFlags
+	restoreRevisionDryRun      bool
+	restoreRevisionYes         bool
+	restoreRevisionConcurrency int
+
+	restoreRevisionLongDescription = `
+The command restores a pad to an earlier revision. The restore is added as new revision, so the history is kept. A
+single pad is restored with the pad and the revision as arguments, which can not be combined with --author and --after.
+
+With --author and --after all pads which were edited by the author after the time are restored to the last revision
+before the first edit of the author. Edits of other authors after that revision are discarded as well. Pads which
+were created by the author are skipped. The time is a RFC3339 timestamp or a duration before now. The planned restores
+are listed and have to be confirmed, unless --yes is set.
+
+Example:
+
+etherpad-toolkit restore-revision pad-keep 120
+etherpad-toolkit restore-revision --author a.Xk3mPq9dRt5sLw2B --after 2021-01-02T03:00:00Z --dry-run
+`
+
+	restoreRevisionCmd = NewRestoreRevisionCmd()
+)
+
+func init() {
+	rootCmd.AddCommand(restoreRevisionCmd)
+}
+
+func NewRestoreRevisionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore-revision [pad] [revision]",
+		Short: "Restores Pads to an earlier revision",
+		Long:  restoreRevisionLongDescription,
+		Run: func(cmd *cobra.Command, args []string) {
+			etherpad := pkg.NewEtherpadClient(etherpadUrl, etherpadApiKey)
+
+			if len(args) == 2 {
+				if restoreRevisionAuthor != "" || restoreRevisionAfter != "" {
+					log.Error("--author and --after can not be combined with a pad and a revision")
+					return
+				}
+				rev, err := strconv.Atoi(args[1])
+				if err != nil {
+					log.WithError(err).Error("failed to parse revision")
+					return
+				}
+				if restoreRevisionDryRun {
+					log.WithFields(log.Fields{"pad": args[0], "revision": rev}).Info("revision would be restored")
+					return
+				}
+				if err = etherpad.RestoreRevision(args[0], rev); err != nil {
+					log.WithError(err).WithFields(log.Fields{"pad": args[0], "revision": rev}).Error("error while restoring revision")
+				} else {
+					log.WithFields(log.Fields{"pad": args[0], "revision": rev}).Info("revision successfully restored")
+				}
+				return
+			}
+
+			if restoreRevisionAuthor == "" || restoreRevisionAfter == "" {
+				cmd.Print(cmd.UsageString())
+				return
+			}
+
+			after, err := parseTime(restoreRevisionAfter)
+			if err != nil {
+				log.WithError(err).Error("failed to parse time")
+				return
+			}
+
+			pads, err := selectPads(etherpad, args, restoreRevisionFilter)
+			if err != nil {
+				log.WithError(err).Error("failed to select pads")
+				return
+			}
+
+			reverter := revert.NewReverter(etherpad, restoreRevisionDryRun)
+			reverter.Concurrency = restoreRevisionConcurrency
+
+			reverts := reverter.Plan(pads, restoreRevisionAuthor, after)
+			if err = revert.WriteReverts(cmd.OutOrStdout(), reverts); err != nil {
+				log.WithError(err).Error("failed to write reverts")
+				return
+			}
+
+			if len(reverts) > 0 && !restoreRevisionYes && !restoreRevisionDryRun && !confirm(cmd, fmt.Sprintf("Restore %d pads?", len(reverts))) {
+				log.Info("aborted")
+				return
+			}
+
+			failed := reverter.Apply(reverts)
+			log.WithFields(log.Fields{"checked": len(pads), "reverted": len(reverts) - failed, "failed": failed}).Info("finished restore")
+		},
+	}
+
+	restoreRevisionFilter.register(cmd)
+	cmd.Flags().StringVar(&restoreRevisionAuthor, "author", "", "Revert the edits of the author.")
+	cmd.Flags().StringVar(&restoreRevisionAfter, "after", "", "Revert the edits after the time, e.g. 2021-01-02T03:04:05Z or 24h.")
+	cmd.Flags().BoolVar(&restoreRevisionDryRun, "dry-run", false, "Enable dry-run")
+	cmd.Flags().BoolVarP(&restoreRevisionYes, "yes", "y", false, "Restore the pads without confirmation.")
+	cmd.Flags().IntVar(&restoreRevisionConcurrency, "concurrency", 4, "Concurrency for the restore process")
+
+	return cmd
+}
+
+// parseTime returns the time of a RFC3339 timestamp or of a duration before now.
+func parseTime(s string) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return time.Now().Add(-d), nil
+	}
+
+	return time.Parse(time.RFC3339, s)
+}
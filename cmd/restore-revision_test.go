@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRestoreRevisionCmd(t *testing.T) {
+	cmd := NewRestoreRevisionCmd()
+	cmd.SetArgs([]string{})
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	err := cmd.Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := ioutil.ReadAll(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, cmd.UsageString(), string(out))
+}
+
+func TestParseTime(t *testing.T) {
+	ts, err := parseTime("2021-01-02T03:04:05Z")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1609556645), ts.Unix())
+
+	ts, err = parseTime("1h")
+	assert.Nil(t, err)
+	assert.WithinDuration(t, time.Now().Add(-time.Hour), ts, time.Second)
+
+	_, err = parseTime("yesterday")
+	assert.NotNil(t, err)
+}
+
+func TestRestoreRevisionCmd_Confirm(t *testing.T) {
+	var mu sync.Mutex
+	var restored []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(r.URL.Path, "/")
+		if parts[1] == "p" {
+			_, _ = w.Write([]byte(`{
+				"pad:notes:revs:0": {"changeset": "Z:1>1+1$a", "meta": {"author": "", "timestamp": 1609556400000}},
+				"pad:notes:revs:1": {"changeset": "Z:2>1+1$b", "meta": {"author": "a.vandal", "timestamp": 1609556700000}}
+			}`))
+			return
+		}
+
+		switch parts[len(parts)-1] {
+		case "listAuthorsOfPad":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"authorIDs": ["a.vandal"]}}`))
+		case "getLastEdited":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"lastEdited": 1609556700000}}`))
+		case "restoreRevision":
+			mu.Lock()
+			restored = append(restored, r.FormValue("padID"))
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": null}`))
+		}
+	}))
+	defer ts.Close()
+
+	url := etherpadUrl
+	etherpadUrl = ts.URL
+	defer func() { etherpadUrl = url }()
+	defer func() {
+		restoreRevisionAuthor, restoreRevisionAfter, restoreRevisionYes, restoreRevisionDryRun = "", "", false, false
+	}()
+
+	cmd := NewRestoreRevisionCmd()
+	cmd.SetArgs([]string{"notes", "--author", "a.vandal", "--after", "2021-01-02T03:00:00Z"})
+	cmd.SetIn(bytes.NewBufferString("\n"))
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+
+	assert.Nil(t, cmd.Execute())
+	assert.Contains(t, b.String(), "Restore 1 pads? [y/N] ")
+	assert.Empty(t, restored)
+
+	cmd = NewRestoreRevisionCmd()
+	cmd.SetArgs([]string{"notes", "--author", "a.vandal", "--after", "2021-01-02T03:00:00Z", "--yes"})
+	cmd.SetOut(bytes.NewBufferString(""))
+
+	assert.Nil(t, cmd.Execute())
+	assert.Equal(t, []string{"notes"}, restored)
+
+	// the single pad form honours --dry-run and can not be combined with --author
+	restored = nil
+	for _, args := range [][]string{{"notes", "1", "--dry-run"}, {"notes", "1", "--author", "a.vandal"}} {
+		cmd = NewRestoreRevisionCmd()
+		cmd.SetArgs(args)
+		cmd.SetOut(bytes.NewBufferString(""))
+
+		assert.Nil(t, cmd.Execute())
+		assert.Empty(t, restored)
+	}
+
+	cmd = NewRestoreRevisionCmd()
+	cmd.SetArgs([]string{"notes", "1"})
+	cmd.SetOut(bytes.NewBufferString(""))
+
+	assert.Nil(t, cmd.Execute())
+	assert.Equal(t, []string{"notes"}, restored)
+}
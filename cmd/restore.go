@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/backup"
+)
+
+var (
+	restoreFilter      padFilterFlags
+	restoreForce       bool
+	restoreDryRun      bool
+	restoreConcurrency int
+	restoreProgress    string
+
+	restoreLongDescription = `
+The command restores pads from an archive which was created by backup. All pads or the pads which match the filter are
+imported with history and chat. The checksums are verified before the import. If force is true and the pad exists, it
+is replaced by a temporary pad into which the file is imported. The pad is kept if the import fails.
+
+With --progress-file the restored pads are recorded. Pads in the file are skipped, so an aborted restore can be resumed
+by running the command again.
+
+Example:
+
+etherpad-toolkit restore etherpad-backup-20210102-030405.tar.gz --suffix keep --progress-file restore.progress
+`
+
+	restoreCmd = NewRestoreCmd()
+)
+
+func init() {
+	rootCmd.AddCommand(restoreCmd)
+}
+
+func NewRestoreCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore [archive]",
+		Short: "Restores Pads from an archive",
+		Long:  restoreLongDescription,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				cmd.Print(cmd.UsageString())
+				return
+			}
+
+			filter, err := restoreFilter.padFilter()
+			if err != nil {
+				log.WithError(err).Error("failed to parse filter")
+				return
+			}
+
+			restorer := backup.NewRestorer(pkg.NewEtherpadClient(etherpadUrl, etherpadApiKey), restoreForce, restoreDryRun)
+			restorer.Filter = filter
+			restorer.Concurrency = restoreConcurrency
+			restorer.ProgressFile = restoreProgress
+
+			result, err := restorer.Restore(args[0])
+			if err != nil {
+				log.WithError(err).WithFields(log.Fields{"restored": result.Restored, "failed": result.Failed}).Error("failed to restore pads")
+				return
+			}
+
+			log.WithFields(log.Fields{"restored": result.Restored, "skipped": result.Skipped, "failed": result.Failed}).Info("finished restore")
+		},
+	}
+
+	restoreFilter.register(cmd)
+	cmd.Flags().BoolVar(&restoreForce, "force", false, "If set and the pad exists, it will be overwritten.")
+	cmd.Flags().BoolVar(&restoreDryRun, "dry-run", false, "Enable dry-run")
+	cmd.Flags().IntVar(&restoreConcurrency, "concurrency", 4, "Concurrency for the restore process")
+	cmd.Flags().StringVar(&restoreProgress, "progress-file", "", "File to record the restored pads. Recorded pads are skipped.")
+
+	return cmd
+}
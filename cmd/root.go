@@ -8,6 +8,9 @@ import (
 )
 
 var (
+	// version is set during the build.
+	version = "dev"
+
 	etherpadUrl    string
 	etherpadApiKey string
 	logLevel       string
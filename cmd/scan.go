@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/remove"
+	"github.com/systemli/etherpad-toolkit/pkg/rename"
+	"github.com/systemli/etherpad-toolkit/pkg/scan"
+)
+
+var (
+	scanFilter          padFilterFlags
+	scanRules           string
+	scanDefaultTextFile string
+	scanOutput          string
+	scanConcurrency     int
+	scanDelete          bool
+	scanQuarantine      string
+	scanBackup          string
+	scanDryRun          bool
+	scanYes             bool
+
+	scanLongDescription = `
+The command fetches the text of all pads or the given pads and scores them with heuristics for spam: link density,
+blocklisted domains and keywords, the same content in multiple pads and bursts of pads which were edited by a single
+author within a short time. The pads are printed ranked by their score, pads with a score above the threshold are
+flagged.
+
+The heuristics are configured with a YAML file. Missing values are taken from the defaults, a heuristic is disabled
+with a score of 0:
+
+threshold: 5
+links:
+  min: 5          # minimum number of links
+  density: 0.1    # minimum links per word
+  score: 3
+domains:
+  list: [spam.example.com]
+  score: 5        # per domain
+keywords:
+  list: [casino, viagra]
+  score: 2        # per keyword
+repeated:
+  min_pads: 3
+  min_length: 50
+  score: 3
+burst:
+  min_pads: 5
+  window: 1h
+  score: 2
+
+Etherpad does not store when a pad was created, so the burst heuristic uses the time of the last edit. Pads which
+were created in a burst and edited later are not detected.
+
+Flagged pads can be deleted with --delete (optionally with --backup) or moved into quarantine with --quarantine, which
+prefixes the pad IDs. The flagged pads have to be confirmed before they are deleted or moved, unless --yes is set.
+
+Example:
+
+etherpad-toolkit scan --rules spam.yaml --quarantine quarantine-
+`
+
+	scanCmd = NewScanCmd()
+)
+
+func init() {
+	rootCmd.AddCommand(scanCmd)
+}
+
+func NewScanCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "scan [pad...]",
+		Short: "Detects spam Pads",
+		Long:  scanLongDescription,
+		Run: func(cmd *cobra.Command, args []string) {
+			if scanDelete && scanQuarantine != "" {
+				log.Error("--delete and --quarantine can not be combined")
+				return
+			}
+
+			rules := scan.DefaultRules()
+			if scanRules != "" {
+				var err error
+				if rules, err = scan.LoadRules(scanRules); err != nil {
+					log.WithError(err).Error("failed to load rules")
+					return
+				}
+			}
+
+			etherpad := pkg.NewEtherpadClient(etherpadUrl, etherpadApiKey)
+			pads, err := selectPads(etherpad, args, scanFilter)
+			if err != nil {
+				log.WithError(err).Error("failed to select pads")
+				return
+			}
+
+			scanner := scan.NewScanner(etherpad, rules)
+			scanner.Concurrency = scanConcurrency
+			if scanDefaultTextFile != "" {
+				text, err := os.ReadFile(scanDefaultTextFile)
+				if err != nil {
+					log.WithError(err).Error("failed to read default pad text")
+					return
+				}
+				scanner.DefaultPadText = string(text)
+			}
+
+			findings := scanner.Scan(pads)
+			if err = scan.Write(cmd.OutOrStdout(), scanOutput, findings); err != nil {
+				log.WithError(err).Error("failed to write report")
+				return
+			}
+
+			flagged := scan.Flagged(findings)
+			log.WithFields(log.Fields{"scanned": len(pads), "findings": len(findings), "flagged": len(flagged)}).Info("finished scan")
+
+			switch {
+			case scanDelete:
+				if len(flagged) > 0 && !scanYes && !scanDryRun && !confirmDeletion(cmd, flagged) {
+					log.Info("aborted")
+					return
+				}
+				deleter := remove.NewDeleter(etherpad, scanDryRun)
+				deleter.Backup = scanBackup
+				deleter.Concurrency = scanConcurrency
+				results, err := deleter.Delete(flagged)
+				if err != nil {
+					log.WithError(err).Error("failed to back up pads")
+					return
+				}
+				log.WithFields(log.Fields{
+					"deleted": remove.Count(results, remove.StatusDeleted) + remove.Count(results, remove.StatusDryRun),
+					"failed":  remove.Count(results, remove.StatusFailed) + remove.Count(results, remove.StatusSkipped),
+				}).Info("deleted flagged pads")
+			case scanQuarantine != "":
+				if len(flagged) > 0 && !scanYes && !scanDryRun && !confirmPads(cmd, flagged, "moved into quarantine") {
+					log.Info("aborted")
+					return
+				}
+				quarantine(etherpad, flagged)
+			}
+		},
+	}
+
+	scanFilter.register(cmd)
+	cmd.Flags().StringVar(&scanRules, "rules", "", "YAML file with the rules for the heuristics.")
+	cmd.Flags().StringVar(&scanDefaultTextFile, "default-text-file", "", "File with the default pad text of Etherpad. Pads with this text are treated as empty.")
+	cmd.Flags().StringVarP(&scanOutput, "output", "o", scan.FormatTable, "Output format: table, json")
+	cmd.Flags().IntVar(&scanConcurrency, "concurrency", 4, "Concurrency for the scan process")
+	cmd.Flags().BoolVar(&scanDelete, "delete", false, "Delete the flagged pads.")
+	cmd.Flags().StringVar(&scanQuarantine, "quarantine", "", "Move the flagged pads to the prefix, e.g. quarantine-.")
+	cmd.Flags().StringVar(&scanBackup, "backup", "", "Path of a tar.gz archive to back up the flagged pads before the deletion.")
+	cmd.Flags().BoolVar(&scanDryRun, "dry-run", false, "Enable dry-run")
+	cmd.Flags().BoolVarP(&scanYes, "yes", "y", false, "Delete or move the flagged pads without confirmation.")
+
+	return cmd
+}
+
+// quarantine moves the pads to the quarantine prefix. Pads are not moved if the destination exists.
+func quarantine(etherpad *pkg.Etherpad, pads []string) {
+	existing, err := etherpad.ListAllPads()
+	if err != nil {
+		log.WithError(err).Error("failed to list all pads")
+		return
+	}
+
+	renames := make([]rename.Rename, 0, len(pads))
+	for _, pad := range pads {
+		renames = append(renames, rename.Rename{Source: pad, Destination: scanQuarantine + pad})
+	}
+
+	batches, err := rename.Plan(renames, existing, false)
+	if err != nil {
+		log.WithError(err).Error("failed to plan quarantine")
+		return
+	}
+
+	mover := rename.NewMover(etherpad, false, scanDryRun)
+	mover.Concurrency = scanConcurrency
+	failed := mover.Execute(batches)
+
+	log.WithFields(log.Fields{"moved": len(renames) - failed, "failed": failed}).Info("moved flagged pads into quarantine")
+}
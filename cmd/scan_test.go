@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanCmd(t *testing.T) {
+	var mu sync.Mutex
+	var moves, deleted []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(r.URL.Path, "/")
+		switch parts[len(parts)-1] {
+		case "listAllPads":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"padIDs": ["spam", "notes"]}}`))
+		case "getText":
+			text := "Meeting notes"
+			if r.FormValue("padID") == "spam" {
+				text = "Visit https://spam.example now"
+			}
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"text": "` + text + `"}}`))
+		case "listAuthorsOfPad":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"authorIDs": []}}`))
+		case "getLastEdited":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"lastEdited": 1609556645000}}`))
+		case "movePad":
+			mu.Lock()
+			moves = append(moves, r.FormValue("sourceID")+" -> "+r.FormValue("destinationID"))
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": null}`))
+		case "deletePad":
+			mu.Lock()
+			deleted = append(deleted, r.FormValue("padID"))
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": null}`))
+		}
+	}))
+	defer ts.Close()
+
+	url := etherpadUrl
+	etherpadUrl = ts.URL
+	defer func() { etherpadUrl = url }()
+	defer func() { scanRules, scanQuarantine, scanDelete, scanYes = "", "", false, false }()
+
+	rules := filepath.Join(t.TempDir(), "rules.yaml")
+	assert.Nil(t, os.WriteFile(rules, []byte("domains:\n  list: [spam.example]\n"), 0o600))
+
+	// the quarantine has to be confirmed
+	cmd := NewScanCmd()
+	cmd.SetArgs([]string{"--rules", rules, "--quarantine", "quarantine-"})
+	cmd.SetIn(bytes.NewBufferString("n\n"))
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+
+	assert.Nil(t, cmd.Execute())
+	assert.Contains(t, b.String(), "The following 1 pads will be moved into quarantine:\n  spam\n")
+	assert.Empty(t, moves)
+
+	cmd = NewScanCmd()
+	cmd.SetArgs([]string{"--rules", rules, "--quarantine", "quarantine-", "--yes"})
+	b.Reset()
+	cmd.SetOut(b)
+
+	assert.Nil(t, cmd.Execute())
+	assert.Equal(t, "SCORE  FLAGGED  PAD   REASONS\n5      yes      spam  domain: spam.example\n", b.String())
+	assert.Equal(t, []string{"spam -> quarantine-spam"}, moves)
+
+	// the deletion has to be confirmed
+	cmd = NewScanCmd()
+	cmd.SetArgs([]string{"--rules", rules, "--delete"})
+	cmd.SetIn(bytes.NewBufferString("n\n"))
+	b.Reset()
+	cmd.SetOut(b)
+
+	assert.Nil(t, cmd.Execute())
+	assert.Contains(t, b.String(), "The following 1 pads will be deleted:\n  spam\n")
+	assert.Empty(t, deleted)
+
+	cmd = NewScanCmd()
+	cmd.SetArgs([]string{"--rules", rules, "--delete", "--yes"})
+	cmd.SetOut(bytes.NewBufferString(""))
+
+	assert.Nil(t, cmd.Execute())
+	assert.Equal(t, []string{"spam"}, deleted)
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/search"
+)
+
+var (
+	searchFilter      padFilterFlags
+	searchRegexp      bool
+	searchIgnoreCase  bool
+	searchIndex       string
+	searchContext     int
+	searchSnippets    int
+	searchColor       bool
+	searchOutput      string
+	searchConcurrency int
+
+	searchLongDescription = `
+The command searches the text of all pads or the pads which match the filters and prints the matching pads with
+snippets of the matches. The query is matched literally, with --regexp it is a regular expression.
+
+With --index the text of the pads is cached in a local file. Only pads which were edited since the last search are
+fetched again, pads which were removed are dropped from the index.
+
+Example:
+
+etherpad-toolkit search "budget 2021" --ignore-case --index /var/cache/etherpad-toolkit/index.json
+etherpad-toolkit search --regexp '\bmeeting (on|at) \d+' --suffix keep --output ids
+`
+
+	searchCmd = NewSearchCmd()
+)
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+}
+
+func NewSearchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "search [query]",
+		Short: "Searches the text of Pads",
+		Long:  searchLongDescription,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 || args[0] == "" {
+				cmd.Print(cmd.UsageString())
+				return
+			}
+
+			pattern, err := search.NewPattern(args[0], searchRegexp, searchIgnoreCase)
+			if err != nil {
+				log.WithError(err).Error("failed to parse query")
+				return
+			}
+
+			filter, err := searchFilter.padFilter()
+			if err != nil {
+				log.WithError(err).Error("failed to parse filter")
+				return
+			}
+
+			etherpad := pkg.NewEtherpadClient(etherpadUrl, etherpadApiKey)
+			pads, err := etherpad.ListAllPads()
+			if err != nil {
+				log.WithError(err).Error("failed to list all pads")
+				return
+			}
+
+			searcher := search.NewSearcher(etherpad)
+			searcher.Concurrency = searchConcurrency
+			searcher.Context = searchContext
+			searcher.Snippets = searchSnippets
+			if searchColor {
+				searcher.Highlight = search.HighlightANSI
+			}
+			if searchIndex != "" {
+				if searcher.Index, err = search.LoadIndex(searchIndex); err != nil {
+					log.WithError(err).Error("failed to load index")
+					return
+				}
+				searcher.Index.Prune(pads)
+			}
+
+			results := searcher.Search(filter.Apply(pads), pattern)
+
+			if searchIndex != "" {
+				if err = searcher.Index.Save(searchIndex); err != nil {
+					log.WithError(err).Error("failed to save index")
+				}
+			}
+
+			if err = search.Write(cmd.OutOrStdout(), searchOutput, results); err != nil {
+				log.WithError(err).Error("failed to write results")
+			}
+		},
+	}
+
+	searchFilter.register(cmd)
+	cmd.Flags().BoolVarP(&searchRegexp, "regexp", "E", false, "Interpret the query as regular expression.")
+	cmd.Flags().BoolVarP(&searchIgnoreCase, "ignore-case", "i", false, "Ignore the case of the query.")
+	cmd.Flags().StringVar(&searchIndex, "index", "", "File to cache the text of the pads.")
+	cmd.Flags().IntVar(&searchContext, "context", 40, "Number of characters around a match in the snippets.")
+	cmd.Flags().IntVar(&searchSnippets, "snippets", 3, "Maximum number of snippets per pad.")
+	cmd.Flags().BoolVar(&searchColor, "color", false, "Highlight the matches with terminal colors instead of **.")
+	cmd.Flags().StringVarP(&searchOutput, "output", "o", search.FormatText, "Output format: text, json, ids")
+	cmd.Flags().IntVar(&searchConcurrency, "concurrency", 4, "Concurrency for fetching the pads")
+
+	return cmd
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/mirror"
+)
+
+var (
+	syncSourceUrl         string
+	syncSourceApiKey      string
+	syncDestinationUrl    string
+	syncDestinationApiKey string
+	syncFilter            padFilterFlags
+	syncConcurrency       int
+	syncDryRun            bool
+	syncInterval          time.Duration
+
+	syncLongDescription = `
+The command copies pads with history and chat from the source to the destination instance. Pads are copied if they
+are missing on the destination or newer on the source, based on the last edit and the number of revisions. Pads which
+were edited later on the destination are skipped. Pads which are removed on the source are not removed on the
+destination.
+
+The source defaults to --etherpad.url and --etherpad.apikey. With --dry-run the planned changes are printed. With
+--interval the command keeps running and syncs the pads periodically.
+
+Example:
+
+etherpad-toolkit sync --source.url https://old.example.org --destination.url https://new.example.org --interval 5m
+`
+
+	syncCmd = NewSyncCmd()
+)
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+}
+
+func NewSyncCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Copies new and changed Pads to another Etherpad",
+		Long:  syncLongDescription,
+		Run: func(cmd *cobra.Command, args []string) {
+			if syncDestinationUrl == "" {
+				cmd.Print(cmd.UsageString())
+				return
+			}
+			if syncSourceUrl == "" {
+				syncSourceUrl = etherpadUrl
+			}
+			if syncSourceApiKey == "" {
+				syncSourceApiKey = etherpadApiKey
+			}
+
+			filter, err := syncFilter.padFilter()
+			if err != nil {
+				log.WithError(err).Error("failed to parse filter")
+				return
+			}
+
+			m := mirror.NewMirror(
+				pkg.NewEtherpadClient(syncSourceUrl, syncSourceApiKey),
+				pkg.NewEtherpadClient(syncDestinationUrl, syncDestinationApiKey),
+			)
+			m.Filter = filter
+			m.Concurrency = syncConcurrency
+
+			if syncInterval > 0 && !syncDryRun {
+				m.Run(cmd.Context(), syncInterval)
+				return
+			}
+
+			changes, failed, err := m.Sync(syncDryRun)
+			if err != nil {
+				log.WithError(err).Error("failed to sync pads")
+				return
+			}
+
+			if syncDryRun {
+				if err = mirror.WriteChanges(cmd.OutOrStdout(), changes); err != nil {
+					log.WithError(err).Error("failed to write changes")
+				}
+				return
+			}
+
+			log.WithFields(log.Fields{"changes": len(changes), "failed": failed}).Info("finished sync")
+		},
+	}
+
+	syncFilter.register(cmd)
+	cmd.Flags().StringVar(&syncSourceUrl, "source.url", "", "URL of the source Etherpad. Defaults to --etherpad.url.")
+	cmd.Flags().StringVar(&syncSourceApiKey, "source.apikey", "", "API Key for the source Etherpad. Defaults to --etherpad.apikey. (Env: SYNC_SOURCE_APIKEY)")
+	cmd.Flags().StringVar(&syncDestinationUrl, "destination.url", "", "URL of the destination Etherpad.")
+	cmd.Flags().StringVar(&syncDestinationApiKey, "destination.apikey", "", "API Key for the destination Etherpad. (Env: SYNC_DESTINATION_APIKEY)")
+	cmd.Flags().IntVar(&syncConcurrency, "concurrency", 4, "Concurrency for the sync process")
+	cmd.Flags().BoolVar(&syncDryRun, "dry-run", false, "Print the planned changes without copying pads.")
+	cmd.Flags().DurationVar(&syncInterval, "interval", 0, "Interval to sync the pads continuously. Syncs once if 0.")
+
+	if os.Getenv("SYNC_SOURCE_APIKEY") != "" {
+		syncSourceApiKey = os.Getenv("SYNC_SOURCE_APIKEY")
+	}
+
+	if os.Getenv("SYNC_DESTINATION_APIKEY") != "" {
+		syncDestinationApiKey = os.Getenv("SYNC_DESTINATION_APIKEY")
+	}
+
+	return cmd
+}
@@ -1,21 +1,61 @@
 package pkg
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 )
 
 const ApiVersion = "1.2.14"
 
+// copyPadWithoutHistoryVersion is the API version of Etherpad 1.8.14 which added copyPadWithoutHistory. It is only used
+// for this method, so the other methods keep working with older versions of Etherpad.
+const copyPadWithoutHistoryVersion = "1.2.15"
+
+// LatestRevision selects the latest revision of a pad.
+const LatestRevision = -1
+
+// ChatMessage is a single message of the pad chat.
+type ChatMessage struct {
+	Text     string `json:"text"`
+	UserID   string `json:"userId"`
+	Time     int64  `json:"time"`
+	UserName string `json:"userName"`
+}
+
+// Stats contains the instance-wide statistics of Etherpad.
+type Stats struct {
+	TotalPads       int `json:"totalPads"`
+	TotalSessions   int `json:"totalSessions"`
+	TotalActivePads int `json:"totalActivePads"`
+}
+
+// APIError is returned if Etherpad responds with an error code.
+type APIError struct {
+	Method  string
+	Code    int
+	Message string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("error: %s (code: %d)", e.Message, e.Code)
+}
+
 // Etherpad
 type Etherpad struct {
 	apiKey     string
 	apiVersion string
 	url        string
 	Client     *http.Client
+
+	// OnError is called for every failed request with the API method and the error.
+	OnError func(method string, err error)
 }
 
 // NewEtherpadClient returns a instance of Etherpad
@@ -50,7 +90,7 @@ func (ep *Etherpad) ListAllPads() ([]string, error) {
 	}
 
 	if body.Code != 0 {
-		return nil, fmt.Errorf("error: %s (code: %d)", body.Message, body.Code)
+		return nil, ep.apiError("listAllPads", body.Code, body.Message)
 	}
 
 	return body.Data.PadIDs, nil
@@ -78,7 +118,7 @@ func (ep *Etherpad) GetLastEdited(padID string) (time.Time, error) {
 	}
 
 	if body.Code != 0 {
-		return time.Unix(0, 0), fmt.Errorf("error: %s (code: %d)", body.Message, body.Code)
+		return time.Unix(0, 0), ep.apiError("getLastEdited", body.Code, body.Message)
 	}
 
 	return time.Unix(body.Data.LastEdited/1000, 0), nil
@@ -103,7 +143,82 @@ func (ep *Etherpad) DeletePad(padID string) error {
 	}
 
 	if body.Code != 0 {
-		return fmt.Errorf("error: %s (code: %d)", body.Message, body.Code)
+		return ep.apiError("deletePad", body.Code, body.Message)
+	}
+
+	return nil
+}
+
+// CreatePad creates a new pad with the text. Etherpad uses the default pad text if the text is empty.
+// See: https://etherpad.org/doc/v1.8.4/#index_createpad_padid_text
+func (ep *Etherpad) CreatePad(padID, text string) error {
+	params := map[string]interface{}{"padID": padID, "text": text}
+	res, err := ep.sendPostRequest("createPad", params)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	var body struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return err
+	}
+
+	if body.Code != 0 {
+		return ep.apiError("createPad", body.Code, body.Message)
+	}
+
+	return nil
+}
+
+// SetText replaces the text of the pad.
+// See: https://etherpad.org/doc/v1.8.4/#index_settext_padid_text
+func (ep *Etherpad) SetText(padID, text string) error {
+	params := map[string]interface{}{"padID": padID, "text": text}
+	res, err := ep.sendPostRequest("setText", params)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	var body struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return err
+	}
+
+	if body.Code != 0 {
+		return ep.apiError("setText", body.Code, body.Message)
+	}
+
+	return nil
+}
+
+// SetHTML replaces the content of the pad with the HTML.
+// See: https://etherpad.org/doc/v1.8.4/#index_sethtml_padid_html
+func (ep *Etherpad) SetHTML(padID, html string) error {
+	params := map[string]interface{}{"padID": padID, "html": html}
+	res, err := ep.sendPostRequest("setHTML", params)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	var body struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return err
+	}
+
+	if body.Code != 0 {
+		return ep.apiError("setHTML", body.Code, body.Message)
 	}
 
 	return nil
@@ -128,7 +243,7 @@ func (ep *Etherpad) MovePad(sourceID, destinationID string, force bool) error {
 	}
 
 	if body.Code != 0 {
-		return fmt.Errorf("error: %s (code: %d)", body.Message, body.Code)
+		return ep.apiError("movePad", body.Code, body.Message)
 	}
 
 	return nil
@@ -153,7 +268,86 @@ func (ep *Etherpad) CopyPad(sourceID, destinationID string, force bool) error {
 	}
 
 	if body.Code != 0 {
-		return fmt.Errorf("error: %s (code: %d)", body.Message, body.Code)
+		return ep.apiError("copyPad", body.Code, body.Message)
+	}
+
+	return nil
+}
+
+// CopyPadWithoutHistory copies a pad without copying the history and chat. If force is true and the destination pad
+// exists, it will be overwritten.
+// See: https://etherpad.org/doc/v1.8.14/#index_copypadwithouthistory_sourceid_destinationid_force_false
+func (ep *Etherpad) CopyPadWithoutHistory(sourceID, destinationID string, force bool) error {
+	params := map[string]interface{}{"sourceID": sourceID, "destinationID": destinationID, "force": force}
+	res, err := ep.send(http.MethodGet, copyPadWithoutHistoryVersion, "copyPadWithoutHistory", params)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	var body struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return err
+	}
+
+	if body.Code != 0 {
+		return ep.apiError("copyPadWithoutHistory", body.Code, body.Message)
+	}
+
+	return nil
+}
+
+// GetChatHistory returns the whole chat history of a pad.
+// See: https://etherpad.org/doc/v1.8.4/#index_getchathistory_padid_start_end
+func (ep *Etherpad) GetChatHistory(padID string) ([]ChatMessage, error) {
+	params := map[string]interface{}{"padID": padID}
+	res, err := ep.sendRequest("getChatHistory", params)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var body struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    struct {
+			Messages []ChatMessage `json:"messages"`
+		} `json:"data"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+
+	if body.Code != 0 {
+		return nil, ep.apiError("getChatHistory", body.Code, body.Message)
+	}
+
+	return body.Data.Messages, nil
+}
+
+// AppendChatMessage creates a chat message for the pad. The time is the unix timestamp in milliseconds.
+// See: https://etherpad.org/doc/v1.8.4/#index_appendchatmessage_padid_text_authorid_time
+func (ep *Etherpad) AppendChatMessage(padID string, message ChatMessage) error {
+	params := map[string]interface{}{"padID": padID, "text": message.Text, "authorID": message.UserID, "time": message.Time}
+	res, err := ep.sendRequest("appendChatMessage", params)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	var body struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return err
+	}
+
+	if body.Code != 0 {
+		return ep.apiError("appendChatMessage", body.Code, body.Message)
 	}
 
 	return nil
@@ -181,29 +375,631 @@ func (ep *Etherpad) GetRevisionsCount(padID string) (int, error) {
 	}
 
 	if body.Code != 0 {
-		return 0, fmt.Errorf("error: %s (code: %d)", body.Message, body.Code)
+		return 0, ep.apiError("getRevisionsCount", body.Code, body.Message)
 	}
 
 	return body.Data.Revisions, nil
 }
 
-func (ep *Etherpad) sendRequest(path string, params map[string]interface{}) (*http.Response, error) {
-	uri, err := url.Parse(fmt.Sprintf("%s/api/%s/%s", ep.url, ep.apiVersion, path))
+// GetText returns the text of a pad.
+// See: https://etherpad.org/doc/v1.8.4/#index_gettext_padid_rev
+func (ep *Etherpad) GetText(padID string) (string, error) {
+	return ep.GetTextAtRevision(padID, LatestRevision)
+}
+
+// GetTextAtRevision returns the text of a pad at the revision.
+// See: https://etherpad.org/doc/v1.8.4/#index_gettext_padid_rev
+func (ep *Etherpad) GetTextAtRevision(padID string, rev int) (string, error) {
+	params := map[string]interface{}{"padID": padID}
+	if rev != LatestRevision {
+		params["rev"] = rev
+	}
+	res, err := ep.sendRequest("getText", params)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	var body struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    struct {
+			Text string `json:"text"`
+		} `json:"data"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", err
+	}
+
+	if body.Code != 0 {
+		return "", ep.apiError("getText", body.Code, body.Message)
+	}
+
+	return body.Data.Text, nil
+}
+
+// GetHTML returns the content of a pad as HTML.
+// See: https://etherpad.org/doc/v1.8.4/#index_gethtml_padid_rev
+func (ep *Etherpad) GetHTML(padID string) (string, error) {
+	return ep.GetHTMLAtRevision(padID, LatestRevision)
+}
+
+// GetHTMLAtRevision returns the content of a pad at the revision as HTML.
+// See: https://etherpad.org/doc/v1.8.4/#index_gethtml_padid_rev
+func (ep *Etherpad) GetHTMLAtRevision(padID string, rev int) (string, error) {
+	params := map[string]interface{}{"padID": padID}
+	if rev != LatestRevision {
+		params["rev"] = rev
+	}
+	res, err := ep.sendRequest("getHTML", params)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	var body struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    struct {
+			HTML string `json:"html"`
+		} `json:"data"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", err
+	}
+
+	if body.Code != 0 {
+		return "", ep.apiError("getHTML", body.Code, body.Message)
+	}
+
+	return body.Data.HTML, nil
+}
+
+// CreateDiffHTML returns the changes between two revisions as HTML and the authors of the changes.
+// See: https://etherpad.org/doc/v1.8.4/#index_creatediffhtml_padid_startrev_endrev
+func (ep *Etherpad) CreateDiffHTML(padID string, startRev, endRev int) (string, []string, error) {
+	params := map[string]interface{}{"padID": padID, "startRev": startRev, "endRev": endRev}
+	res, err := ep.sendRequest("createDiffHTML", params)
+	if err != nil {
+		return "", nil, err
+	}
+	defer res.Body.Close()
+
+	var body struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    struct {
+			HTML    string   `json:"html"`
+			Authors []string `json:"authors"`
+		} `json:"data"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", nil, err
+	}
+
+	if body.Code != 0 {
+		return "", nil, ep.apiError("createDiffHTML", body.Code, body.Message)
+	}
+
+	return body.Data.HTML, body.Data.Authors, nil
+}
+
+// RestoreRevision restores the pad to the revision. The restore is added as new revision.
+// See: https://etherpad.org/doc/v1.8.4/#index_restorerevision_padid_rev
+func (ep *Etherpad) RestoreRevision(padID string, rev int) error {
+	params := map[string]interface{}{"padID": padID, "rev": rev}
+	res, err := ep.sendRequest("restoreRevision", params)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	var body struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return err
+	}
+
+	if body.Code != 0 {
+		return ep.apiError("restoreRevision", body.Code, body.Message)
+	}
+
+	return nil
+}
+
+// PadUsersCount returns the number of users which are currently connected to the pad.
+// See: https://etherpad.org/doc/v1.8.4/#index_paduserscount_padid
+func (ep *Etherpad) PadUsersCount(padID string) (int, error) {
+	params := map[string]interface{}{"padID": padID}
+	res, err := ep.sendRequest("padUsersCount", params)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	var body struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    struct {
+			PadUsersCount int `json:"padUsersCount"`
+		} `json:"data"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return 0, err
+	}
+
+	if body.Code != 0 {
+		return 0, ep.apiError("padUsersCount", body.Code, body.Message)
+	}
+
+	return body.Data.PadUsersCount, nil
+}
+
+// ListAuthorsOfPad returns the IDs of all authors who contributed to the pad.
+// See: https://etherpad.org/doc/v1.8.4/#index_listauthorsofpad_padid
+func (ep *Etherpad) ListAuthorsOfPad(padID string) ([]string, error) {
+	params := map[string]interface{}{"padID": padID}
+	res, err := ep.sendRequest("listAuthorsOfPad", params)
 	if err != nil {
 		return nil, err
 	}
+	defer res.Body.Close()
 
-	parameters := url.Values{}
-	parameters.Add("apikey", ep.apiKey)
-	for key, value := range params {
-		parameters.Add(key, fmt.Sprintf("%v", value))
+	var body struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    struct {
+			AuthorIDs []string `json:"authorIDs"`
+		} `json:"data"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+
+	if body.Code != 0 {
+		return nil, ep.apiError("listAuthorsOfPad", body.Code, body.Message)
 	}
-	uri.RawQuery = parameters.Encode()
 
-	req, err := http.NewRequest(http.MethodGet, uri.String(), nil)
+	return body.Data.AuthorIDs, nil
+}
+
+// ListSavedRevisions returns the revision numbers of the saved revisions of the pad.
+// See: https://etherpad.org/doc/v1.8.4/#index_listsavedrevisions_padid
+func (ep *Etherpad) ListSavedRevisions(padID string) ([]int, error) {
+	params := map[string]interface{}{"padID": padID}
+	res, err := ep.sendRequest("listSavedRevisions", params)
 	if err != nil {
 		return nil, err
 	}
+	defer res.Body.Close()
+
+	var body struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    struct {
+			SavedRevisions []int `json:"savedRevisions"`
+		} `json:"data"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+
+	if body.Code != 0 {
+		return nil, ep.apiError("listSavedRevisions", body.Code, body.Message)
+	}
+
+	return body.Data.SavedRevisions, nil
+}
+
+// GetReadOnlyID returns the read only link of the pad.
+// See: https://etherpad.org/doc/v1.8.4/#index_getreadonlyid_padid
+func (ep *Etherpad) GetReadOnlyID(padID string) (string, error) {
+	params := map[string]interface{}{"padID": padID}
+	res, err := ep.sendRequest("getReadOnlyID", params)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	var body struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    struct {
+			ReadOnlyID string `json:"readOnlyID"`
+		} `json:"data"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", err
+	}
+
+	if body.Code != 0 {
+		return "", ep.apiError("getReadOnlyID", body.Code, body.Message)
+	}
+
+	return body.Data.ReadOnlyID, nil
+}
+
+// GetPublicStatus returns true if the group pad is public. Only works for group pads.
+// See: https://etherpad.org/doc/v1.8.4/#index_getpublicstatus_padid
+func (ep *Etherpad) GetPublicStatus(padID string) (bool, error) {
+	params := map[string]interface{}{"padID": padID}
+	res, err := ep.sendRequest("getPublicStatus", params)
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+
+	var body struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    struct {
+			PublicStatus bool `json:"publicStatus"`
+		} `json:"data"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return false, err
+	}
+
+	if body.Code != 0 {
+		return false, ep.apiError("getPublicStatus", body.Code, body.Message)
+	}
+
+	return body.Data.PublicStatus, nil
+}
+
+// IsPasswordProtected returns true if the group pad is protected with a password. Only works for group pads.
+// See: https://etherpad.org/doc/v1.8.4/#index_ispasswordprotected_padid
+func (ep *Etherpad) IsPasswordProtected(padID string) (bool, error) {
+	params := map[string]interface{}{"padID": padID}
+	res, err := ep.sendRequest("isPasswordProtected", params)
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+
+	var body struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    struct {
+			IsPasswordProtected bool `json:"isPasswordProtected"`
+		} `json:"data"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return false, err
+	}
+
+	if body.Code != 0 {
+		return false, ep.apiError("isPasswordProtected", body.Code, body.Message)
+	}
+
+	return body.Data.IsPasswordProtected, nil
+}
+
+// GetChatHead returns the index of the last chat message of the pad, -1 if the chat is empty.
+// See: https://etherpad.org/doc/v1.8.4/#index_getchathead_padid
+func (ep *Etherpad) GetChatHead(padID string) (int, error) {
+	params := map[string]interface{}{"padID": padID}
+	res, err := ep.sendRequest("getChatHead", params)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	var body struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    struct {
+			ChatHead int `json:"chatHead"`
+		} `json:"data"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return 0, err
+	}
+
+	if body.Code != 0 {
+		return 0, ep.apiError("getChatHead", body.Code, body.Message)
+	}
+
+	return body.Data.ChatHead, nil
+}
+
+// GetAuthorName returns the name of the author.
+// See: https://etherpad.org/doc/v1.8.4/#index_getauthorname_authorid
+func (ep *Etherpad) GetAuthorName(authorID string) (string, error) {
+	params := map[string]interface{}{"authorID": authorID}
+	res, err := ep.sendRequest("getAuthorName", params)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	var body struct {
+		Code    int             `json:"code"`
+		Message string          `json:"message"`
+		Data    json.RawMessage `json:"data"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", err
+	}
+
+	if body.Code != 0 {
+		return "", ep.apiError("getAuthorName", body.Code, body.Message)
+	}
+
+	// Etherpad returns the name as string, older versions wrap it in an object.
+	var name string
+	if err = json.Unmarshal(body.Data, &name); err == nil {
+		return name, nil
+	}
+	var data struct {
+		AuthorName string `json:"authorName"`
+	}
+	if err = json.Unmarshal(body.Data, &data); err != nil {
+		return "", err
+	}
+
+	return data.AuthorName, nil
+}
+
+// ListPadsOfAuthor returns the pads the author contributed to.
+// See: https://etherpad.org/doc/v1.8.4/#index_listpadsofauthor_authorid
+func (ep *Etherpad) ListPadsOfAuthor(authorID string) ([]string, error) {
+	params := map[string]interface{}{"authorID": authorID}
+	res, err := ep.sendRequest("listPadsOfAuthor", params)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var body struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    struct {
+			PadIDs []string `json:"padIDs"`
+		} `json:"data"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+
+	if body.Code != 0 {
+		return nil, ep.apiError("listPadsOfAuthor", body.Code, body.Message)
+	}
+
+	return body.Data.PadIDs, nil
+}
+
+// CreateAuthorIfNotExistsFor returns the author ID for the mapper. The author is created if the mapper is unknown.
+// See: https://etherpad.org/doc/v1.8.4/#index_createauthorifnotexistsfor_authormapper_name
+func (ep *Etherpad) CreateAuthorIfNotExistsFor(authorMapper string) (string, error) {
+	return ep.createAuthorIfNotExistsFor(map[string]interface{}{"authorMapper": authorMapper})
+}
+
+// SetAuthorNameForMapper sets the name of the author for the mapper and returns the author ID. Etherpad ignores an
+// empty name.
+// See: https://etherpad.org/doc/v1.8.4/#index_createauthorifnotexistsfor_authormapper_name
+func (ep *Etherpad) SetAuthorNameForMapper(authorMapper, name string) (string, error) {
+	return ep.createAuthorIfNotExistsFor(map[string]interface{}{"authorMapper": authorMapper, "name": name})
+}
+
+func (ep *Etherpad) createAuthorIfNotExistsFor(params map[string]interface{}) (string, error) {
+	res, err := ep.sendRequest("createAuthorIfNotExistsFor", params)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	var body struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    struct {
+			AuthorID string `json:"authorID"`
+		} `json:"data"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", err
+	}
+
+	if body.Code != 0 {
+		return "", ep.apiError("createAuthorIfNotExistsFor", body.Code, body.Message)
+	}
+
+	return body.Data.AuthorID, nil
+}
+
+// GetStats returns the instance-wide statistics.
+// See: https://etherpad.org/doc/v1.8.4/#index_getstats
+func (ep *Etherpad) GetStats() (Stats, error) {
+	res, err := ep.sendRequest("getStats", nil)
+	if err != nil {
+		return Stats{}, err
+	}
+	defer res.Body.Close()
+
+	var body struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    Stats  `json:"data"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return Stats{}, err
+	}
+
+	if body.Code != 0 {
+		return Stats{}, ep.apiError("getStats", body.Code, body.Message)
+	}
+
+	return body.Data, nil
+}
+
+// CheckToken returns an error if the API key is invalid.
+// See: https://etherpad.org/doc/v1.8.4/#index_checktoken
+func (ep *Etherpad) CheckToken() error {
+	res, err := ep.sendRequest("checkToken", nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	var body struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return err
+	}
+
+	if body.Code != 0 {
+		return ep.apiError("checkToken", body.Code, body.Message)
+	}
+
+	return nil
+}
+
+// GetAPIVersion returns the latest API version which is supported by Etherpad.
+func (ep *Etherpad) GetAPIVersion() (string, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/api", ep.url), nil)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := ep.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	var body struct {
+		CurrentVersion string `json:"currentVersion"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return "", err
+	}
+
+	return body.CurrentVersion, nil
+}
+
+// ExportPad downloads the pad in the format via the export endpoint of Etherpad, e.g. "etherpad" for the full pad
+// with history and chat. The endpoint is not part of the HTTP API and does not use the API key.
+func (ep *Etherpad) ExportPad(padID, format string, rev int) ([]byte, error) {
+	path := url.PathEscape(padID)
+	if rev != LatestRevision {
+		path = fmt.Sprintf("%s/%d", path, rev)
+	}
+
+	res, err := ep.Client.Get(fmt.Sprintf("%s/p/%s/export/%s", ep.url, path, format))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to export pad: %s", res.Status)
+	}
+
+	return io.ReadAll(res.Body)
+}
+
+// ImportPad uploads the file via the import endpoint of Etherpad. The format is detected by the file extension, e.g.
+// ".etherpad" for a pad with history and chat. Etherpad imports .etherpad files only into pads without history.
+// The endpoint is not part of the HTTP API and does not use the API key.
+func (ep *Etherpad) ImportPad(padID, filename string, data []byte) error {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return err
+	}
+	if _, err = part.Write(data); err != nil {
+		return err
+	}
+	if err = writer.Close(); err != nil {
+		return err
+	}
+
+	res, err := ep.Client.Post(fmt.Sprintf("%s/p/%s/import", ep.url, url.PathEscape(padID)), writer.FormDataContentType(), &buf)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to import pad: %s", res.Status)
+	}
+
+	// Etherpad responds with JSON since 1.8.14, older versions respond with a script which contains the status.
+	var body struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	if err = json.Unmarshal(b, &body); err != nil {
+		if !strings.Contains(string(b), "'ok'") {
+			return fmt.Errorf("failed to import pad: %s", strings.TrimSpace(string(b)))
+		}
+		return nil
+	}
+	if body.Code != 0 {
+		return fmt.Errorf("failed to import pad: %s", body.Message)
+	}
+
+	return nil
+}
+
+func (ep *Etherpad) apiError(method string, code int, message string) error {
+	err := &APIError{Method: method, Code: code, Message: message}
+	if ep.OnError != nil {
+		ep.OnError(method, err)
+	}
+
+	return err
+}
+
+func (ep *Etherpad) sendRequest(path string, params map[string]interface{}) (*http.Response, error) {
+	return ep.send(http.MethodGet, ep.apiVersion, path, params)
+}
+
+// sendPostRequest sends the parameters in the request body, e.g. for the content of pads which exceeds the URL length.
+func (ep *Etherpad) sendPostRequest(path string, params map[string]interface{}) (*http.Response, error) {
+	return ep.send(http.MethodPost, ep.apiVersion, path, params)
+}
+
+func (ep *Etherpad) send(method, version, path string, params map[string]interface{}) (*http.Response, error) {
+	uri, err := url.Parse(fmt.Sprintf("%s/api/%s/%s", ep.url, version, path))
+	if err != nil {
+		return nil, err
+	}
+
+	parameters := url.Values{}
+	parameters.Add("apikey", ep.apiKey)
+	for key, value := range params {
+		parameters.Add(key, fmt.Sprintf("%v", value))
+	}
+
+	var body io.Reader
+	if method == http.MethodGet {
+		uri.RawQuery = parameters.Encode()
+	} else {
+		body = strings.NewReader(parameters.Encode())
+	}
+
+	req, err := http.NewRequest(method, uri.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	res, err := ep.Client.Do(req)
+	if err != nil && ep.OnError != nil {
+		ep.OnError(path, err)
+	}
 
-	return ep.Client.Do(req)
+	return res, err
 }
@@ -1,6 +1,7 @@
 package pkg
 
 import (
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -197,3 +198,806 @@ func TestEtherpad_GetRevisionsCount_NotFound(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Equal(t, 0, rev)
 }
+
+func TestEtherpad_GetText_Successful(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"text": "Hello World\n"}}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	text, err := etherpad.GetText("pad")
+	assert.Nil(t, err)
+	assert.Equal(t, "Hello World\n", text)
+}
+
+func TestEtherpad_GetText_NotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 1, "message":"padID does not exist", "data": null}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	text, err := etherpad.GetText("pad")
+	assert.NotNil(t, err)
+	assert.Empty(t, text)
+}
+
+func TestEtherpad_CopyPadWithoutHistory_Successful(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/1.2.15/copyPadWithoutHistory", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": null}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	err := etherpad.CopyPadWithoutHistory("pad1", "pad2", false)
+	assert.Nil(t, err)
+}
+
+func TestEtherpad_CopyPadWithoutHistory_NotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 1, "message":"padID does not exist", "data": null}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	err := etherpad.CopyPadWithoutHistory("pad1", "pad2", false)
+	assert.NotNil(t, err)
+}
+
+func TestEtherpad_GetChatHistory_Successful(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"messages": [{"text":"foo","userId":"a.foo","time":1359199533759,"userName":"test"}]}}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	messages, err := etherpad.GetChatHistory("pad")
+	assert.Nil(t, err)
+	assert.Equal(t, []ChatMessage{{Text: "foo", UserID: "a.foo", Time: 1359199533759, UserName: "test"}}, messages)
+}
+
+func TestEtherpad_GetChatHistory_NotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 1, "message":"padID does not exist", "data": null}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	messages, err := etherpad.GetChatHistory("pad")
+	assert.NotNil(t, err)
+	assert.Empty(t, messages)
+}
+
+func TestEtherpad_AppendChatMessage_Successful(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "a.foo", r.URL.Query().Get("authorID"))
+		assert.Equal(t, "1359199533759", r.URL.Query().Get("time"))
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": null}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	err := etherpad.AppendChatMessage("pad", ChatMessage{Text: "foo", UserID: "a.foo", Time: 1359199533759})
+	assert.Nil(t, err)
+}
+
+func TestEtherpad_AppendChatMessage_NotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 1, "message":"padID does not exist", "data": null}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	err := etherpad.AppendChatMessage("pad", ChatMessage{Text: "foo", UserID: "a.foo", Time: 1359199533759})
+	assert.NotNil(t, err)
+}
+
+func TestEtherpad_GetTextAtRevision(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"text": "rev ` + r.URL.Query().Get("rev") + `"}}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	text, err := etherpad.GetTextAtRevision("pad", 5)
+	assert.Nil(t, err)
+	assert.Equal(t, "rev 5", text)
+}
+
+func TestEtherpad_GetHTML_Successful(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		assert.False(t, r.URL.Query().Has("rev"))
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"html": "<!DOCTYPE HTML><html><body>Hello World<br></body></html>"}}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	html, err := etherpad.GetHTML("pad")
+	assert.Nil(t, err)
+	assert.Equal(t, "<!DOCTYPE HTML><html><body>Hello World<br></body></html>", html)
+}
+
+func TestEtherpad_GetHTML_NotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 1, "message":"padID does not exist", "data": null}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	html, err := etherpad.GetHTML("pad")
+	assert.NotNil(t, err)
+	assert.Empty(t, html)
+}
+
+func TestEtherpad_CreateDiffHTML_Successful(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		assert.Equal(t, "1", r.URL.Query().Get("startRev"))
+		assert.Equal(t, "4", r.URL.Query().Get("endRev"))
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"html": "<style></style>Hello <span class=\"removed\">World</span>", "authors": ["a.1", ""]}}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	html, authors, err := etherpad.CreateDiffHTML("pad", 1, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, `<style></style>Hello <span class="removed">World</span>`, html)
+	assert.Equal(t, []string{"a.1", ""}, authors)
+}
+
+func TestEtherpad_CreateDiffHTML_NotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 1, "message":"startRev is higher than the latest revision of the pad", "data": null}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	html, _, err := etherpad.CreateDiffHTML("pad", 10, 20)
+	assert.NotNil(t, err)
+	assert.Empty(t, html)
+}
+
+func TestEtherpad_RestoreRevision_Successful(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		assert.Equal(t, "/api/"+ApiVersion+"/restoreRevision", r.URL.Path)
+		assert.Equal(t, "7", r.URL.Query().Get("rev"))
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": null}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	err := etherpad.RestoreRevision("pad", 7)
+	assert.Nil(t, err)
+}
+
+func TestEtherpad_RestoreRevision_Invalid(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 1, "message":"rev is higher than the head revision of the pad", "data": null}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	err := etherpad.RestoreRevision("pad", 99)
+	assert.NotNil(t, err)
+}
+
+func TestEtherpad_PadUsersCount_Successful(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"padUsersCount": 5}}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	count, err := etherpad.PadUsersCount("pad")
+	assert.Nil(t, err)
+	assert.Equal(t, 5, count)
+}
+
+func TestEtherpad_PadUsersCount_NotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 1, "message":"padID does not exist", "data": null}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	count, err := etherpad.PadUsersCount("pad")
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestEtherpad_ListAuthorsOfPad_Successful(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"authorIDs": ["a.1", "a.2"]}}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	authors, err := etherpad.ListAuthorsOfPad("pad")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a.1", "a.2"}, authors)
+}
+
+func TestEtherpad_ListAuthorsOfPad_NotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 1, "message":"padID does not exist", "data": null}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	authors, err := etherpad.ListAuthorsOfPad("pad")
+	assert.NotNil(t, err)
+	assert.Nil(t, authors)
+}
+
+func TestEtherpad_ListSavedRevisions_Successful(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"savedRevisions": [2, 42]}}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	revisions, err := etherpad.ListSavedRevisions("pad")
+	assert.Nil(t, err)
+	assert.Equal(t, []int{2, 42}, revisions)
+}
+
+func TestEtherpad_ListSavedRevisions_NotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 1, "message":"padID does not exist", "data": null}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	revisions, err := etherpad.ListSavedRevisions("pad")
+	assert.NotNil(t, err)
+	assert.Nil(t, revisions)
+}
+
+func TestEtherpad_GetReadOnlyID_Successful(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"readOnlyID": "r.abc"}}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	id, err := etherpad.GetReadOnlyID("pad")
+	assert.Nil(t, err)
+	assert.Equal(t, "r.abc", id)
+}
+
+func TestEtherpad_GetReadOnlyID_NotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 1, "message":"padID does not exist", "data": null}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	id, err := etherpad.GetReadOnlyID("pad")
+	assert.NotNil(t, err)
+	assert.Empty(t, id)
+}
+
+func TestEtherpad_GetPublicStatus_Successful(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"publicStatus": true}}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	public, err := etherpad.GetPublicStatus("g.abc$pad")
+	assert.Nil(t, err)
+	assert.Equal(t, true, public)
+}
+
+func TestEtherpad_GetPublicStatus_NoGroupPad(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 1, "message":"You can only get/set the publicStatus of pads that belong to a group", "data": null}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	public, err := etherpad.GetPublicStatus("g.abc$pad")
+	assert.NotNil(t, err)
+	assert.False(t, public)
+}
+
+func TestEtherpad_IsPasswordProtected_Successful(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"isPasswordProtected": true}}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	protected, err := etherpad.IsPasswordProtected("g.abc$pad")
+	assert.Nil(t, err)
+	assert.Equal(t, true, protected)
+}
+
+func TestEtherpad_IsPasswordProtected_NoGroupPad(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 1, "message":"You can only get/set the password of pads that belong to a group", "data": null}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	protected, err := etherpad.IsPasswordProtected("g.abc$pad")
+	assert.NotNil(t, err)
+	assert.False(t, protected)
+}
+
+func TestEtherpad_GetChatHead_Successful(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"chatHead": 41}}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	head, err := etherpad.GetChatHead("pad")
+	assert.Nil(t, err)
+	assert.Equal(t, 41, head)
+}
+
+func TestEtherpad_GetChatHead_NotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 1, "message":"padID does not exist", "data": null}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	head, err := etherpad.GetChatHead("pad")
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, head)
+}
+
+func TestEtherpad_GetAuthorName_Successful(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": "John McLear"}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	name, err := etherpad.GetAuthorName("a.abc")
+	assert.Nil(t, err)
+	assert.Equal(t, "John McLear", name)
+}
+
+func TestEtherpad_GetAuthorName_NotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 1, "message":"authorID does not exist", "data": null}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	name, err := etherpad.GetAuthorName("a.abc")
+	assert.NotNil(t, err)
+	assert.Empty(t, name)
+}
+
+func TestEtherpad_GetAuthorName_Object(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"authorName": "John McLear"}}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	name, err := etherpad.GetAuthorName("a.abc")
+	assert.Nil(t, err)
+	assert.Equal(t, "John McLear", name)
+}
+
+func TestEtherpad_ListPadsOfAuthor_Successful(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		assert.Equal(t, "a.1", r.URL.Query().Get("authorID"))
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"padIDs": ["pad1", "pad2"]}}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	pads, err := etherpad.ListPadsOfAuthor("a.1")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"pad1", "pad2"}, pads)
+}
+
+func TestEtherpad_ListPadsOfAuthor_NotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 1, "message":"authorID does not exist", "data": null}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	pads, err := etherpad.ListPadsOfAuthor("a.1")
+	assert.NotNil(t, err)
+	assert.Empty(t, pads)
+}
+
+func TestEtherpad_CreateAuthorIfNotExistsFor(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		assert.Equal(t, "user-7", r.URL.Query().Get("authorMapper"))
+		assert.False(t, r.URL.Query().Has("name"))
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"authorID": "a.1"}}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	author, err := etherpad.CreateAuthorIfNotExistsFor("user-7")
+	assert.Nil(t, err)
+	assert.Equal(t, "a.1", author)
+}
+
+func TestEtherpad_SetAuthorNameForMapper(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		assert.Equal(t, "user-7", r.URL.Query().Get("authorMapper"))
+		assert.Equal(t, "Anonymous", r.URL.Query().Get("name"))
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"authorID": "a.1"}}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	author, err := etherpad.SetAuthorNameForMapper("user-7", "Anonymous")
+	assert.Nil(t, err)
+	assert.Equal(t, "a.1", author)
+}
+
+func TestEtherpad_GetStats_Successful(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"totalPads": 3, "totalSessions": 2, "totalActivePads": 1}}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	stats, err := etherpad.GetStats()
+	assert.Nil(t, err)
+	assert.Equal(t, Stats{TotalPads: 3, TotalSessions: 2, TotalActivePads: 1}, stats)
+}
+
+func TestEtherpad_GetStats_NotSupported(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 3, "message":"no such function", "data": null}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	stats, err := etherpad.GetStats()
+	assert.NotNil(t, err)
+	assert.Equal(t, Stats{}, stats)
+}
+
+func TestEtherpad_CheckToken_Successful(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": null}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	err := etherpad.CheckToken()
+	assert.Nil(t, err)
+}
+
+func TestEtherpad_CheckToken_WrongApiKey(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code":4,"message":"no or wrong API Key","data":null}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	err := etherpad.CheckToken()
+	assert.NotNil(t, err)
+}
+
+func TestEtherpad_GetAPIVersion(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"currentVersion":"1.2.15"}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	version, err := etherpad.GetAPIVersion()
+	assert.Nil(t, err)
+	assert.Equal(t, "1.2.15", version)
+}
+
+func TestEtherpad_OnError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code":4,"message":"no or wrong API Key","data":null}`))
+	}))
+	defer ts.Close()
+
+	var methods []string
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+	etherpad.OnError = func(method string, err error) {
+		methods = append(methods, method)
+	}
+
+	_, err := etherpad.ListAllPads()
+	assert.Equal(t, &APIError{Method: "listAllPads", Code: 4, Message: "no or wrong API Key"}, err)
+	assert.Equal(t, "error: no or wrong API Key (code: 4)", err.Error())
+
+	etherpad = NewEtherpadClient("http://127.0.0.1:0", etherpadApiKey)
+	etherpad.OnError = func(method string, err error) {
+		methods = append(methods, method)
+	}
+
+	_, err = etherpad.ListAllPads()
+	assert.NotNil(t, err)
+	assert.Equal(t, []string{"listAllPads", "listAllPads"}, methods)
+}
+
+func TestEtherpad_ExportPad(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/p/g.abc$pad/export/etherpad":
+			_, _ = w.Write([]byte(`{"pad:g.abc$pad":{}}`))
+		case "/p/pad/3/export/txt":
+			_, _ = w.Write([]byte("Hello"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	b, err := etherpad.ExportPad("g.abc$pad", "etherpad", LatestRevision)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"pad:g.abc$pad":{}}`, string(b))
+
+	b, err = etherpad.ExportPad("pad", "txt", 3)
+	assert.Nil(t, err)
+	assert.Equal(t, "Hello", string(b))
+
+	_, err = etherpad.ExportPad("unknown", "txt", LatestRevision)
+	assert.Error(t, err)
+}
+
+func TestEtherpad_CreatePad(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "Hello World", r.FormValue("text"))
+		w.WriteHeader(http.StatusOK)
+		if r.FormValue("padID") == "exists" {
+			_, _ = w.Write([]byte(`{"code": 1, "message":"padID does already exist", "data": null}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": null}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	err := etherpad.CreatePad("pad", "Hello World")
+	assert.Nil(t, err)
+
+	err = etherpad.CreatePad("exists", "Hello World")
+	assert.NotNil(t, err)
+}
+
+func TestEtherpad_SetText(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Hello World", r.FormValue("text"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": null}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	err := etherpad.SetText("pad", "Hello World")
+	assert.Nil(t, err)
+}
+
+func TestEtherpad_SetHTML(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "<b>Hello</b>", r.FormValue("html"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"code": 1, "message":"HTML is malformed", "data": null}`))
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	err := etherpad.SetHTML("pad", "<b>Hello</b>")
+	assert.NotNil(t, err)
+}
+
+func TestEtherpad_ImportPad(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("file")
+		assert.Nil(t, err)
+		b, _ := io.ReadAll(file)
+		assert.Equal(t, "pad.etherpad", header.Filename)
+		assert.Equal(t, "{}", string(b))
+
+		switch r.URL.Path {
+		case "/p/new/import":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"directDatabaseAccess": true}}`))
+		case "/p/legacy/import":
+			_, _ = w.Write([]byte(`<script>parent.padimpexp.handleFrameCall('true', 'ok');</script>`))
+		case "/p/existing/import":
+			_, _ = w.Write([]byte(`{"code": 1, "message":"padHasData", "data": null}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	etherpad := NewEtherpadClient(ts.URL, etherpadApiKey)
+	etherpad.Client = ts.Client()
+
+	assert.Nil(t, etherpad.ImportPad("new", "pad.etherpad", []byte("{}")))
+	assert.Nil(t, etherpad.ImportPad("legacy", "pad.etherpad", []byte("{}")))
+	assert.EqualError(t, etherpad.ImportPad("existing", "pad.etherpad", []byte("{}")), "failed to import pad: padHasData")
+	assert.Error(t, etherpad.ImportPad("unknown", "pad.etherpad", []byte("{}")))
+}
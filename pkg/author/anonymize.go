@@ -0,0 +1,150 @@
+package author
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/export"
+	"github.com/systemli/etherpad-toolkit/pkg/helper"
+	"github.com/systemli/etherpad-toolkit/pkg/importer"
+)
+
+// Report records the anonymization of an author. It contains no personal data besides the IDs.
+type Report struct {
+	Author      string      `json:"author"`
+	Mapper      string      `json:"mapper,omitempty"`
+	Time        time.Time   `json:"time"`
+	DryRun      bool        `json:"dryRun"`
+	NameCleared bool        `json:"nameCleared"`
+	Note        string      `json:"note,omitempty"`
+	Pads        []PadReport `json:"pads"`
+}
+
+// PadReport records the changes of a pad.
+type PadReport struct {
+	Pad                 string `json:"pad"`
+	ChatMessagesRemoved int    `json:"chatMessagesRemoved"`
+	Error               string `json:"error,omitempty"`
+}
+
+type Anonymizer struct {
+	etherpad *pkg.Etherpad
+	dryRun   bool
+
+	// Name replaces the name of the author. Etherpad ignores empty names.
+	Name string
+	// Concurrency is the number of pads which are rewritten in parallel.
+	Concurrency int
+}
+
+// NewAnonymizer returns a instance of Anonymizer.
+func NewAnonymizer(ep *pkg.Etherpad, dryRun bool) *Anonymizer {
+	return &Anonymizer{
+		etherpad:    ep,
+		dryRun:      dryRun,
+		Name:        "Anonymous",
+		Concurrency: 4,
+	}
+}
+
+// Anonymize replaces the name of the author and, if removeChat is set, removes the chat messages of the author from
+// all pads the author contributed to. The Etherpad API can only change the name with the mapper of the author, e.g.
+// the user ID of a single sign-on. The chat messages are removed by rewriting the pads: the pad is downloaded in the
+// .etherpad format and replaced by a pad which is imported without the messages. The text and the history of the pad
+// are kept.
+func (a *Anonymizer) Anonymize(author, mapper string, removeChat bool) (Report, error) {
+	report := Report{
+		Author: author,
+		Mapper: mapper,
+		Time:   time.Now().UTC(),
+		DryRun: a.dryRun,
+		Pads:   []PadReport{},
+	}
+
+	if a.Name == "" {
+		return report, errors.New("name must not be empty")
+	}
+
+	pads, err := a.etherpad.ListPadsOfAuthor(author)
+	if err != nil {
+		return report, err
+	}
+	sort.Strings(pads)
+
+	if mapper == "" {
+		report.Note = "the name can only be replaced with the author mapper"
+	} else {
+		// an unknown mapper creates a new author, so the mapper is checked before the name is set
+		id, err := a.etherpad.CreateAuthorIfNotExistsFor(mapper)
+		if err != nil {
+			return report, err
+		}
+		if id != author {
+			if found, err := NewFinder(a.etherpad).Get(id); err == nil && found.Empty() {
+				return report, fmt.Errorf("mapper is unknown, Etherpad created the empty author %s for it", id)
+			}
+			return report, fmt.Errorf("mapper belongs to author %s", id)
+		}
+
+		log.WithField("author", author).Info("Replace Author Name")
+		if !a.dryRun {
+			if _, err = a.etherpad.SetAuthorNameForMapper(mapper, a.Name); err != nil {
+				return report, err
+			}
+		}
+		report.NameCleared = true
+	}
+
+	report.Pads = make([]PadReport, len(pads))
+	helper.ForEach(a.Concurrency, len(pads), func(i int) {
+		padReport := PadReport{Pad: pads[i]}
+		if removeChat {
+			removed, err := a.removeChat(pads[i], author)
+			if err != nil {
+				log.WithError(err).WithField("pad", pads[i]).Error("failed to remove chat messages")
+				padReport.Error = err.Error()
+			}
+			padReport.ChatMessagesRemoved = removed
+		}
+		report.Pads[i] = padReport
+	})
+
+	return report, nil
+}
+
+// removeChat rewrites the pad without the chat messages of the author and returns the number of removed messages.
+// Pads without messages of the author are not changed.
+func (a *Anonymizer) removeChat(pad, author string) (int, error) {
+	data, err := a.etherpad.ExportPad(pad, export.FormatEtherpad, pkg.LatestRevision)
+	if err != nil {
+		return 0, err
+	}
+	e, err := parseExport(pad, data)
+	if err != nil {
+		return 0, err
+	}
+
+	removed, err := e.removeChat(author)
+	if err != nil || removed == 0 {
+		return 0, err
+	}
+	// the author is imported with the pad, so the name is replaced as well
+	if _, err = e.renameAuthor(author, a.Name); err != nil {
+		return 0, err
+	}
+	if data, err = e.marshal(); err != nil {
+		return 0, err
+	}
+
+	log.WithFields(log.Fields{"pad": pad, "messages": removed}).Info("Remove Chat Messages")
+	err = importer.NewImporter(a.etherpad, true, a.dryRun).ImportData(pad, export.FileName(pad, export.FormatEtherpad), data, true)
+	if err != nil {
+		return 0, err
+	}
+
+	return removed, nil
+}
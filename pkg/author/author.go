@@ -0,0 +1,211 @@
+package author
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/export"
+	"github.com/systemli/etherpad-toolkit/pkg/helper"
+	"github.com/systemli/etherpad-toolkit/pkg/history"
+)
+
+// Author is an author of Etherpad with the pads the author contributed to.
+type Author struct {
+	ID   string   `json:"id"`
+	Name string   `json:"name"`
+	Pads []string `json:"pads"`
+}
+
+// Empty returns true if the author has no name and no pads, e.g. an author which Etherpad created for an unknown
+// mapper.
+func (a Author) Empty() bool {
+	return a.Name == "" && len(a.Pads) == 0
+}
+
+// Data is everything the author wrote, e.g. for a data subject access request.
+type Data struct {
+	Author   string    `json:"author"`
+	Name     string    `json:"name"`
+	Exported time.Time `json:"exported"`
+	Pads     []PadData `json:"pads"`
+}
+
+// PadData is the contribution of the author to a pad.
+type PadData struct {
+	Pad string `json:"pad"`
+	// Text are the parts of the current text which are attributed to the author.
+	Text []string `json:"text"`
+	// Revisions are the numbers of the revisions which were created by the author.
+	Revisions []int             `json:"revisions"`
+	Chat      []pkg.ChatMessage `json:"chat"`
+	Error     string            `json:"error,omitempty"`
+}
+
+type Finder struct {
+	etherpad *pkg.Etherpad
+
+	// Concurrency is the number of pads and authors which are fetched in parallel.
+	Concurrency int
+}
+
+// NewFinder returns a instance of Finder.
+func NewFinder(ep *pkg.Etherpad) *Finder {
+	return &Finder{
+		etherpad:    ep,
+		Concurrency: 4,
+	}
+}
+
+// FindByName returns the authors of the pads whose name contains the name, ignoring the case. Etherpad has no index
+// of the authors, so the authors of every pad are fetched.
+func (f *Finder) FindByName(name string, pads []string) []Author {
+	var mu sync.Mutex
+	authors := make(map[string]*Author)
+	helper.ForEach(f.Concurrency, len(pads), func(i int) {
+		ids, err := f.etherpad.ListAuthorsOfPad(pads[i])
+		if err != nil {
+			log.WithError(err).WithField("pad", pads[i]).Warn("failed to list authors")
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		for _, id := range ids {
+			if authors[id] == nil {
+				authors[id] = &Author{ID: id}
+			}
+			authors[id].Pads = append(authors[id].Pads, pads[i])
+		}
+	})
+
+	candidates := make([]*Author, 0, len(authors))
+	for _, author := range authors {
+		candidates = append(candidates, author)
+	}
+
+	var found []Author
+	helper.ForEach(f.Concurrency, len(candidates), func(i int) {
+		author := candidates[i]
+		authorName, err := f.etherpad.GetAuthorName(author.ID)
+		if err != nil {
+			log.WithError(err).WithField("author", author.ID).Warn("failed to get author name")
+			return
+		}
+		if authorName == "" || !strings.Contains(strings.ToLower(authorName), strings.ToLower(name)) {
+			return
+		}
+
+		mu.Lock()
+		author.Name = authorName
+		sort.Strings(author.Pads)
+		found = append(found, *author)
+		mu.Unlock()
+	})
+
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].Name != found[j].Name {
+			return found[i].Name < found[j].Name
+		}
+		return found[i].ID < found[j].ID
+	})
+
+	return found
+}
+
+// FindByMapper returns the author of the mapper, e.g. the user ID of a single sign-on. Etherpad has no lookup without
+// side effects, so a new empty author is created if the mapper is unknown.
+func (f *Finder) FindByMapper(mapper string) (Author, error) {
+	id, err := f.etherpad.CreateAuthorIfNotExistsFor(mapper)
+	if err != nil {
+		return Author{}, err
+	}
+
+	return f.Get(id)
+}
+
+// Get returns the author with the name and the pads.
+func (f *Finder) Get(id string) (Author, error) {
+	name, err := f.etherpad.GetAuthorName(id)
+	if err != nil {
+		return Author{}, err
+	}
+	pads, err := f.etherpad.ListPadsOfAuthor(id)
+	if err != nil {
+		return Author{}, err
+	}
+	sort.Strings(pads)
+
+	return Author{ID: id, Name: name, Pads: pads}, nil
+}
+
+// Export returns everything the author wrote: the attributed text, the revisions and the chat messages of every pad
+// the author contributed to. The pads are downloaded in the .etherpad format, failures are recorded per pad.
+func (f *Finder) Export(id string) (Data, error) {
+	author, err := f.Get(id)
+	if err != nil {
+		return Data{}, err
+	}
+
+	data := Data{
+		Author:   author.ID,
+		Name:     author.Name,
+		Exported: time.Now().UTC(),
+		Pads:     make([]PadData, len(author.Pads)),
+	}
+	helper.ForEach(f.Concurrency, len(author.Pads), func(i int) {
+		pad := author.Pads[i]
+		padData, err := f.export(pad, id)
+		if err != nil {
+			log.WithError(err).WithField("pad", pad).Error("failed to export pad")
+			padData = PadData{Pad: pad, Error: err.Error()}
+		}
+		data.Pads[i] = padData
+	})
+
+	return data, nil
+}
+
+func (f *Finder) export(pad, author string) (PadData, error) {
+	padData := PadData{Pad: pad, Text: []string{}, Revisions: []int{}, Chat: []pkg.ChatMessage{}}
+
+	raw, err := f.etherpad.ExportPad(pad, export.FormatEtherpad, pkg.LatestRevision)
+	if err != nil {
+		return padData, err
+	}
+	e, err := parseExport(pad, raw)
+	if err != nil {
+		return padData, err
+	}
+
+	text, err := e.authoredText(author)
+	if err != nil {
+		return padData, err
+	}
+	padData.Text = append(padData.Text, text...)
+
+	revisions, err := history.ParseExport(raw)
+	if err != nil {
+		return padData, err
+	}
+	for _, rev := range revisions {
+		if rev.Author == author {
+			padData.Revisions = append(padData.Revisions, rev.Number)
+		}
+	}
+
+	entries, err := e.chat()
+	if err != nil {
+		return padData, err
+	}
+	for _, entry := range entries {
+		if entry.message.UserID == author {
+			padData.Chat = append(padData.Chat, entry.message)
+		}
+	}
+
+	return padData, nil
+}
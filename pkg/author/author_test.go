@@ -0,0 +1,230 @@
+package author
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/etherpadtest"
+)
+
+// fake is an Etherpad with the authors Alice and Bob which keeps the names and the imported pads.
+type fake struct {
+	*etherpadtest.Fake
+	names    map[string]string
+	imported map[string]string
+}
+
+func newFake() *fake {
+	f := &fake{
+		names:    map[string]string{"a.alice": "Alice", "a.bob": "Bob"},
+		imported: make(map[string]string),
+	}
+	f.Fake = &etherpadtest.Fake{
+		Responses: map[string]etherpadtest.Response{
+			"listAuthorsOfPad": func(r *http.Request) string {
+				if r.FormValue("padID") == "todo" {
+					return `{"authorIDs": ["a.bob"]}`
+				}
+				return `{"authorIDs": ["a.alice", "a.bob"]}`
+			},
+			"getAuthorName": func(r *http.Request) string {
+				return `"` + f.names[r.FormValue("authorID")] + `"`
+			},
+			"listPadsOfAuthor": func(r *http.Request) string {
+				switch r.FormValue("authorID") {
+				case "a.alice":
+					return `{"padIDs": ["notes"]}`
+				case "a.bob":
+					return `{"padIDs": ["todo", "notes", "gone"]}`
+				}
+				return `{"padIDs": []}`
+			},
+			"createAuthorIfNotExistsFor": func(r *http.Request) string {
+				author := "a.new"
+				switch r.FormValue("authorMapper") {
+				case "user-alice":
+					author = "a.alice"
+				case "user-bob":
+					author = "a.bob"
+				}
+				if name := r.FormValue("name"); name != "" {
+					f.names[author] = name
+				}
+				return `{"authorID": "` + author + `"}`
+			},
+			"movePad": func(r *http.Request) string {
+				f.imported[r.FormValue("destinationID")] = f.imported[r.FormValue("sourceID")]
+				delete(f.imported, r.FormValue("sourceID"))
+				return "null"
+			},
+		},
+		Export: func(pad string) string {
+			if pad != "notes" {
+				return ""
+			}
+			return padData
+		},
+		Import: func(pad string, data []byte) {
+			f.imported[pad] = string(data)
+		},
+		Missing: func(pad string) bool {
+			return strings.Contains(pad, "-tmp-")
+		},
+	}
+
+	return f
+}
+
+func TestFinder_FindByName(t *testing.T) {
+	ts := etherpadtest.NewServer(newFake().Fake)
+	defer ts.Close()
+
+	finder := NewFinder(pkg.NewEtherpadClient(ts.URL, ""))
+
+	authors := finder.FindByName("ali", []string{"notes", "todo"})
+	assert.Equal(t, []Author{{ID: "a.alice", Name: "Alice", Pads: []string{"notes"}}}, authors)
+
+	authors = finder.FindByName("B", []string{"notes", "todo"})
+	assert.Equal(t, []Author{{ID: "a.bob", Name: "Bob", Pads: []string{"notes", "todo"}}}, authors)
+
+	assert.Empty(t, finder.FindByName("carol", []string{"notes", "todo"}))
+}
+
+func TestFinder_FindByMapper(t *testing.T) {
+	ts := etherpadtest.NewServer(newFake().Fake)
+	defer ts.Close()
+
+	finder := NewFinder(pkg.NewEtherpadClient(ts.URL, ""))
+
+	author, err := finder.FindByMapper("user-alice")
+	assert.Nil(t, err)
+	assert.Equal(t, Author{ID: "a.alice", Name: "Alice", Pads: []string{"notes"}}, author)
+}
+
+func TestAuthor_Empty(t *testing.T) {
+	assert.True(t, Author{ID: "a.new"}.Empty())
+	assert.False(t, Author{ID: "a.alice", Name: "Alice"}.Empty())
+	assert.False(t, Author{ID: "a.alice", Pads: []string{"notes"}}.Empty())
+}
+
+func TestFinder_Export(t *testing.T) {
+	ts := etherpadtest.NewServer(newFake().Fake)
+	defer ts.Close()
+
+	finder := NewFinder(pkg.NewEtherpadClient(ts.URL, ""))
+
+	data, err := finder.Export("a.bob")
+	assert.Nil(t, err)
+	assert.Equal(t, "a.bob", data.Author)
+	assert.Equal(t, "Bob", data.Name)
+	assert.Len(t, data.Pads, 3)
+
+	assert.Equal(t, "gone", data.Pads[0].Pad)
+	assert.Equal(t, "failed to export pad: 404 Not Found", data.Pads[0].Error)
+
+	assert.Equal(t, PadData{
+		Pad:       "notes",
+		Text:      []string{"World"},
+		Revisions: []int{1},
+		Chat:      []pkg.ChatMessage{{Text: "hello", UserID: "a.bob", Time: 1609556500000}},
+	}, data.Pads[1])
+
+	var b bytes.Buffer
+	assert.Nil(t, WriteJSON(&b, data))
+	assert.Contains(t, b.String(), `"text": [`)
+}
+
+func TestAnonymizer_Anonymize(t *testing.T) {
+	f := newFake()
+	ts := etherpadtest.NewServer(f.Fake)
+	defer ts.Close()
+
+	anonymizer := NewAnonymizer(pkg.NewEtherpadClient(ts.URL, ""), false)
+
+	report, err := anonymizer.Anonymize("a.alice", "user-alice", true)
+	assert.Nil(t, err)
+	assert.True(t, report.NameCleared)
+	assert.Equal(t, []PadReport{{Pad: "notes", ChatMessagesRemoved: 2}}, report.Pads)
+	assert.Equal(t, "Anonymous", f.names["a.alice"])
+	assert.Equal(t, []string{"import notes-import-tmp-*", "movePad notes-import-tmp-* notes"}, f.Calls("import", "movePad"))
+	assert.Empty(t, f.Calls("deletePad"))
+
+	e, err := parseExport("notes", []byte(f.imported["notes"]))
+	assert.Nil(t, err)
+	entries, err := e.chat()
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+	assert.NotContains(t, f.imported["notes"], "Alice")
+
+	var b bytes.Buffer
+	assert.Nil(t, WriteJSON(&b, report))
+	assert.NotContains(t, b.String(), "Alice")
+}
+
+func TestAnonymizer_Anonymize_WithoutMapper(t *testing.T) {
+	f := newFake()
+	ts := etherpadtest.NewServer(f.Fake)
+	defer ts.Close()
+
+	anonymizer := NewAnonymizer(pkg.NewEtherpadClient(ts.URL, ""), false)
+
+	report, err := anonymizer.Anonymize("a.bob", "", false)
+	assert.Nil(t, err)
+	assert.False(t, report.NameCleared)
+	assert.NotEmpty(t, report.Note)
+	assert.Equal(t, []PadReport{{Pad: "gone"}, {Pad: "notes"}, {Pad: "todo"}}, report.Pads)
+	assert.Empty(t, f.Calls("deletePad"))
+	assert.Empty(t, f.imported)
+}
+
+func TestAnonymizer_Anonymize_WrongMapper(t *testing.T) {
+	f := newFake()
+	ts := etherpadtest.NewServer(f.Fake)
+	defer ts.Close()
+
+	anonymizer := NewAnonymizer(pkg.NewEtherpadClient(ts.URL, ""), false)
+
+	_, err := anonymizer.Anonymize("a.alice", "user-bob", true)
+	assert.Equal(t, "mapper belongs to author a.bob", err.Error())
+	assert.Equal(t, "Alice", f.names["a.alice"])
+	assert.Empty(t, f.imported)
+
+	_, err = anonymizer.Anonymize("a.alice", "user-carol", true)
+	assert.Equal(t, "mapper is unknown, Etherpad created the empty author a.new for it", err.Error())
+	assert.Equal(t, "Alice", f.names["a.alice"])
+	assert.Empty(t, f.imported)
+}
+
+func TestAnonymizer_Anonymize_DryRun(t *testing.T) {
+	f := newFake()
+	ts := etherpadtest.NewServer(f.Fake)
+	defer ts.Close()
+
+	anonymizer := NewAnonymizer(pkg.NewEtherpadClient(ts.URL, ""), true)
+
+	report, err := anonymizer.Anonymize("a.alice", "user-alice", true)
+	assert.Nil(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, []PadReport{{Pad: "notes", ChatMessagesRemoved: 2}}, report.Pads)
+	assert.Equal(t, "Alice", f.names["a.alice"])
+	assert.Empty(t, f.Calls("deletePad"))
+	assert.Empty(t, f.imported)
+}
+
+func TestWrite(t *testing.T) {
+	authors := []Author{{ID: "a.alice", Name: "Alice", Pads: []string{"notes"}}}
+
+	var b bytes.Buffer
+	assert.Nil(t, Write(&b, FormatTable, authors))
+	assert.Equal(t, "AUTHOR   NAME   PADS\na.alice  Alice  1\n", b.String())
+
+	b.Reset()
+	assert.Nil(t, Write(&b, FormatJSON, nil))
+	assert.Equal(t, "[]\n", b.String())
+
+	assert.NotNil(t, Write(&b, "xml", authors))
+}
@@ -0,0 +1,192 @@
+package author
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/systemli/etherpad-toolkit/pkg"
+)
+
+var (
+	chatKey  = regexp.MustCompile(`^pad:.+:chat:(\d+)$`)
+	opFormat = regexp.MustCompile(`((?:\*[0-9a-z]+)*)(?:\|[0-9a-z]+)?\+([0-9a-z]+)`)
+)
+
+// padExport is a pad in the .etherpad format.
+type padExport struct {
+	pad     string
+	entries map[string]json.RawMessage
+}
+
+type chatEntry struct {
+	number  int
+	message pkg.ChatMessage
+	raw     json.RawMessage
+}
+
+func parseExport(pad string, data []byte) (*padExport, error) {
+	e := &padExport{pad: pad}
+	if err := json.Unmarshal(data, &e.entries); err != nil {
+		return nil, err
+	}
+	if _, ok := e.entries["pad:"+pad]; !ok {
+		return nil, fmt.Errorf("pad %s is missing in the export", pad)
+	}
+
+	return e, nil
+}
+
+// authoredText returns the parts of the current text which are attributed to the author.
+func (e *padExport) authoredText(author string) ([]string, error) {
+	var p struct {
+		AText struct {
+			Text    string `json:"text"`
+			Attribs string `json:"attribs"`
+		} `json:"atext"`
+		Pool struct {
+			NumToAttrib map[string][]string `json:"numToAttrib"`
+		} `json:"pool"`
+	}
+	if err := json.Unmarshal(e.entries["pad:"+e.pad], &p); err != nil {
+		return nil, err
+	}
+
+	attribs := make(map[int64]bool)
+	for key, attrib := range p.Pool.NumToAttrib {
+		num, err := strconv.ParseInt(key, 10, 64)
+		if err == nil && len(attrib) == 2 && attrib[0] == "author" && attrib[1] == author {
+			attribs[num] = true
+		}
+	}
+
+	// Etherpad counts the characters in UTF-16 code units
+	text := utf16.Encode([]rune(p.AText.Text))
+	var parts []string
+	var current []uint16
+	pos := 0
+	for _, op := range opFormat.FindAllStringSubmatch(p.AText.Attribs, -1) {
+		chars, err := strconv.ParseInt(op[2], 36, 64)
+		if err != nil {
+			return nil, err
+		}
+		end := pos + int(chars)
+		if end > len(text) {
+			return nil, fmt.Errorf("invalid attributes of pad %s", e.pad)
+		}
+
+		authored := false
+		for _, ref := range strings.Split(op[1], "*")[1:] {
+			if num, err := strconv.ParseInt(ref, 36, 64); err == nil && attribs[num] {
+				authored = true
+			}
+		}
+		if authored {
+			current = append(current, text[pos:end]...)
+		} else if len(current) > 0 {
+			parts = appendPart(parts, current)
+			current = nil
+		}
+		pos = end
+	}
+	if len(current) > 0 {
+		parts = appendPart(parts, current)
+	}
+
+	return parts, nil
+}
+
+// chat returns the chat messages of the pad ordered by their number.
+func (e *padExport) chat() ([]chatEntry, error) {
+	var entries []chatEntry
+	for key, value := range e.entries {
+		match := chatKey.FindStringSubmatch(key)
+		if match == nil || !strings.HasPrefix(key, "pad:"+e.pad+":") {
+			continue
+		}
+
+		var message pkg.ChatMessage
+		if err := json.Unmarshal(value, &message); err != nil {
+			return nil, err
+		}
+		number, _ := strconv.Atoi(match[1])
+		entries = append(entries, chatEntry{number: number, message: message, raw: value})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].number < entries[j].number })
+
+	return entries, nil
+}
+
+// removeChat removes the chat messages of the author and renumbers the remaining messages. Returns the number of
+// removed messages.
+func (e *padExport) removeChat(author string) (int, error) {
+	entries, err := e.chat()
+	if err != nil {
+		return 0, err
+	}
+
+	var keep []json.RawMessage
+	for _, entry := range entries {
+		delete(e.entries, fmt.Sprintf("pad:%s:chat:%d", e.pad, entry.number))
+		if entry.message.UserID != author {
+			keep = append(keep, entry.raw)
+		}
+	}
+	for i, raw := range keep {
+		e.entries[fmt.Sprintf("pad:%s:chat:%d", e.pad, i)] = raw
+	}
+
+	// the numbers in the pad entry are decoded as json.Number to keep them unchanged
+	decoder := json.NewDecoder(bytes.NewReader(e.entries["pad:"+e.pad]))
+	decoder.UseNumber()
+	var p map[string]interface{}
+	if err = decoder.Decode(&p); err != nil {
+		return 0, err
+	}
+	p["chatHead"] = len(keep) - 1
+	if e.entries["pad:"+e.pad], err = json.Marshal(p); err != nil {
+		return 0, err
+	}
+
+	return len(entries) - len(keep), nil
+}
+
+// renameAuthor replaces the name of the author in the export. Returns false if the author is missing.
+func (e *padExport) renameAuthor(author, name string) (bool, error) {
+	key := "globalAuthor:" + author
+	data, ok := e.entries[key]
+	if !ok {
+		return false, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var a map[string]interface{}
+	if err := decoder.Decode(&a); err != nil {
+		return false, err
+	}
+	a["name"] = name
+	var err error
+	if e.entries[key], err = json.Marshal(a); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (e *padExport) marshal() ([]byte, error) {
+	return json.Marshal(e.entries)
+}
+
+func appendPart(parts []string, part []uint16) []string {
+	if text := strings.TrimSpace(string(utf16.Decode(part))); text != "" {
+		parts = append(parts, text)
+	}
+
+	return parts
+}
@@ -0,0 +1,106 @@
+package author
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const padData = `{
+	"pad:notes": {
+		"atext": {"text": "Hello World\nbye\n", "attribs": "*0+6*1+5|1+1*0|1+4"},
+		"pool": {"numToAttrib": {"0": ["author", "a.alice"], "1": ["author", "a.bob"]}, "nextNum": 2},
+		"head": 2,
+		"chatHead": 2,
+		"savedRevisions": []
+	},
+	"pad:notes:revs:0": {"changeset": "Z:1>6*0+6$Hello ", "meta": {"author": "a.alice", "timestamp": 1609556400000}},
+	"pad:notes:revs:1": {"changeset": "Z:7>6*1+6$World\n", "meta": {"author": "a.bob", "timestamp": 1609556500000}},
+	"pad:notes:revs:2": {"changeset": "Z:d>4*0+4$bye\n", "meta": {"author": "a.alice", "timestamp": 1609556600000}},
+	"pad:notes:chat:0": {"text": "hi", "userId": "a.alice", "time": 1609556400000},
+	"pad:notes:chat:1": {"text": "hello", "userId": "a.bob", "time": 1609556500000},
+	"pad:notes:chat:2": {"text": "bye", "userId": "a.alice", "time": 1609556600000},
+	"globalAuthor:a.alice": {"colorId": 3, "name": "Alice", "timestamp": 1609556400000, "padIDs": "notes"},
+	"globalAuthor:a.bob": {"colorId": 5, "name": "Bob", "timestamp": 1609556500000, "padIDs": "notes"}
+}`
+
+func TestParseExport(t *testing.T) {
+	_, err := parseExport("notes", []byte(padData))
+	assert.Nil(t, err)
+
+	_, err = parseExport("other", []byte(padData))
+	assert.NotNil(t, err)
+
+	_, err = parseExport("notes", []byte("<html>"))
+	assert.NotNil(t, err)
+}
+
+func TestPadExport_AuthoredText(t *testing.T) {
+	e, err := parseExport("notes", []byte(padData))
+	assert.Nil(t, err)
+
+	text, err := e.authoredText("a.alice")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Hello", "bye"}, text)
+
+	text, err = e.authoredText("a.bob")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"World"}, text)
+
+	text, err = e.authoredText("a.unknown")
+	assert.Nil(t, err)
+	assert.Empty(t, text)
+}
+
+func TestPadExport_AuthoredText_UTF16(t *testing.T) {
+	data := `{"pad:emoji": {"atext": {"text": "😀 ok\n", "attribs": "*0+3*1+2|1+1"}, "pool": {"numToAttrib": {"0": ["author", "a.alice"], "1": ["author", "a.bob"]}}}}`
+	e, err := parseExport("emoji", []byte(data))
+	assert.Nil(t, err)
+
+	text, err := e.authoredText("a.alice")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"😀"}, text)
+
+	text, err = e.authoredText("a.bob")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ok"}, text)
+}
+
+func TestPadExport_RemoveChat(t *testing.T) {
+	e, err := parseExport("notes", []byte(padData))
+	assert.Nil(t, err)
+
+	removed, err := e.removeChat("a.alice")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, removed)
+
+	entries, err := e.chat()
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, 0, entries[0].number)
+	assert.Equal(t, "hello", entries[0].message.Text)
+
+	var p map[string]interface{}
+	assert.Nil(t, json.Unmarshal(e.entries["pad:notes"], &p))
+	assert.Equal(t, float64(0), p["chatHead"])
+	assert.Equal(t, float64(2), p["head"])
+}
+
+func TestPadExport_RenameAuthor(t *testing.T) {
+	e, err := parseExport("notes", []byte(padData))
+	assert.Nil(t, err)
+
+	ok, err := e.renameAuthor("a.alice", "Anonymous")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	ok, err = e.renameAuthor("a.unknown", "Anonymous")
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	data, err := e.marshal()
+	assert.Nil(t, err)
+	assert.NotContains(t, string(data), "Alice")
+	assert.Contains(t, string(data), "Bob")
+}
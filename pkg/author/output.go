@@ -0,0 +1,50 @@
+package author
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+)
+
+// Write writes the authors in the format.
+func Write(w io.Writer, format string, authors []Author) error {
+	switch format {
+	case FormatTable:
+		return writeTable(w, authors)
+	case FormatJSON:
+		if authors == nil {
+			authors = []Author{}
+		}
+		return WriteJSON(w, authors)
+	}
+
+	return fmt.Errorf("unknown format: %s", format)
+}
+
+// WriteJSON writes the value as indented JSON, e.g. the exported data or the report.
+func WriteJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func writeTable(w io.Writer, authors []Author) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "AUTHOR\tNAME\tPADS"); err != nil {
+		return err
+	}
+
+	for _, author := range authors {
+		if _, err := fmt.Fprintf(tw, "%s\t%s\t%d\n", author.ID, author.Name, len(author.Pads)); err != nil {
+			return err
+		}
+	}
+
+	return tw.Flush()
+}
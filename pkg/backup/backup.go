@@ -0,0 +1,135 @@
+package backup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"path"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/export"
+	"github.com/systemli/etherpad-toolkit/pkg/helper"
+)
+
+const (
+	// Version is the version of the archive format.
+	Version = 1
+	// ManifestFile is the name of the manifest in the archive.
+	ManifestFile = "manifest.json"
+	// PadDir is the directory of the pads in the archive.
+	PadDir = "pads"
+)
+
+// Entry is a pad in the backup.
+type Entry struct {
+	Pad        string    `json:"pad"`
+	File       string    `json:"file,omitempty"`
+	Size       int       `json:"size"`
+	SHA256     string    `json:"sha256,omitempty"`
+	Revisions  int       `json:"revisions"`
+	LastEdited time.Time `json:"lastEdited"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Manifest lists the pads in the backup. It is written as last file into the archive.
+type Manifest struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"createdAt"`
+	Pads      []Entry   `json:"pads"`
+}
+
+// Failed returns the number of pads which failed to back up.
+func (m *Manifest) Failed() int {
+	failed := 0
+	for _, entry := range m.Pads {
+		if entry.Error != "" {
+			failed++
+		}
+	}
+
+	return failed
+}
+
+type Backup struct {
+	etherpad *pkg.Etherpad
+	exporter *export.Exporter
+
+	// Concurrency is the number of pads which are downloaded in parallel.
+	Concurrency int
+}
+
+// NewBackup returns a instance of Backup.
+func NewBackup(ep *pkg.Etherpad) *Backup {
+	exporter, _ := export.NewExporter(ep, export.FormatEtherpad)
+
+	return &Backup{
+		etherpad:    ep,
+		exporter:    exporter,
+		Concurrency: 4,
+	}
+}
+
+// Create downloads the pads with history and chat into the target and writes the manifest. Pads which fail to back
+// up are listed with the error in the manifest. An error is returned if the target can not be written.
+func (b *Backup) Create(pads []string, target export.Target) (*Manifest, error) {
+	manifest := &Manifest{Version: Version, CreatedAt: time.Now(), Pads: make([]Entry, len(pads))}
+
+	var mu sync.Mutex
+	var targetErr error
+	helper.ForEach(b.Concurrency, len(pads), func(i int) {
+		entry, data, err := b.download(pads[i])
+		if err != nil {
+			log.WithError(err).WithField("pad", pads[i]).Error("failed to back up pad")
+			entry.Error = err.Error()
+			manifest.Pads[i] = entry
+			return
+		}
+
+		mu.Lock()
+		if err = target.Write(entry.File, data); err != nil && targetErr == nil {
+			targetErr = err
+		}
+		mu.Unlock()
+
+		log.WithFields(log.Fields{"pad": pads[i], "size": entry.Size}).Debug("pad backed up")
+		manifest.Pads[i] = entry
+	})
+
+	if targetErr != nil {
+		return manifest, targetErr
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return manifest, err
+	}
+
+	return manifest, target.Write(ManifestFile, data)
+}
+
+func (b *Backup) download(pad string) (Entry, []byte, error) {
+	var err error
+	entry := Entry{Pad: pad}
+
+	if entry.Revisions, err = b.etherpad.GetRevisionsCount(pad); err != nil {
+		return entry, nil, err
+	}
+	if entry.LastEdited, err = b.etherpad.GetLastEdited(pad); err != nil {
+		return entry, nil, err
+	}
+
+	data, err := b.exporter.Download(pad)
+	if err != nil {
+		return entry, nil, err
+	}
+
+	sum := sha256.Sum256(data)
+	entry.File = path.Join(PadDir, export.FileName(pad, export.FormatEtherpad))
+	entry.Size = len(data)
+	entry.SHA256 = hex.EncodeToString(sum[:])
+
+	return entry, data, nil
+}
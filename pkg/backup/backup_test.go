@@ -0,0 +1,66 @@
+package backup
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/etherpadtest"
+	"github.com/systemli/etherpad-toolkit/pkg/export"
+)
+
+func newFake() *etherpadtest.Fake {
+	return &etherpadtest.Fake{
+		Responses: map[string]etherpadtest.Response{
+			"listAllPads":       etherpadtest.Data(`{"padIDs": ["pad1"]}`),
+			"getRevisionsCount": etherpadtest.Data(`{"revisions": 7}`),
+			"getLastEdited":     etherpadtest.Data(`{"lastEdited": 1609556645000}`),
+		},
+		Export: func(pad string) string {
+			if pad == "broken" {
+				return ""
+			}
+			return `{"pad:` + pad + `":{}}`
+		},
+		Missing: func(pad string) bool {
+			return strings.Contains(pad, "-tmp-")
+		},
+	}
+}
+
+// writes returns the calls which change pads.
+func writes(fake *etherpadtest.Fake) []string {
+	return fake.Calls("import", "deletePad", "movePad")
+}
+
+func createBackup(t *testing.T, url string, pads []string) string {
+	archive := filepath.Join(t.TempDir(), "backup.tar.gz")
+	target, err := export.NewArchiveTarget(archive)
+	assert.Nil(t, err)
+
+	_, err = NewBackup(pkg.NewEtherpadClient(url, "")).Create(pads, target)
+	assert.Nil(t, err)
+	assert.Nil(t, target.Close())
+
+	return archive
+}
+
+func TestBackup_Create(t *testing.T) {
+	ts := etherpadtest.NewServer(newFake())
+	defer ts.Close()
+
+	archive := createBackup(t, ts.URL, []string{"pad1", "g.abc$pad2", "broken"})
+
+	manifest, err := ReadManifest(archive)
+	assert.Nil(t, err)
+	assert.Equal(t, Version, manifest.Version)
+	assert.Len(t, manifest.Pads, 3)
+	assert.Equal(t, 1, manifest.Failed())
+	assert.Equal(t, "pads/g.abc$pad2.etherpad", manifest.Pads[1].File)
+	assert.Equal(t, 7, manifest.Pads[1].Revisions)
+	assert.Equal(t, int64(1609556645), manifest.Pads[1].LastEdited.Unix())
+	assert.NotEmpty(t, manifest.Pads[1].SHA256)
+	assert.NotEmpty(t, manifest.Pads[2].Error)
+}
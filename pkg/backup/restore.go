@@ -0,0 +1,237 @@
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/helper"
+	"github.com/systemli/etherpad-toolkit/pkg/importer"
+)
+
+// Result contains the number of restored, skipped and failed pads.
+type Result struct {
+	Restored int
+	Skipped  int
+	Failed   int
+}
+
+type Restorer struct {
+	etherpad *pkg.Etherpad
+	importer *importer.Importer
+	dryRun   bool
+
+	// Filter selects the pads to restore. All pads are restored if nil.
+	Filter *helper.PadFilter
+	// Concurrency is the number of pads which are imported in parallel.
+	Concurrency int
+	// ProgressFile records the restored pads. Pads in the file are skipped, which allows to resume a restore.
+	ProgressFile string
+}
+
+type job struct {
+	entry Entry
+	data  []byte
+}
+
+// NewRestorer returns a instance of Restorer. If force is true and the pad exists, it will be overwritten.
+func NewRestorer(ep *pkg.Etherpad, force, dryRun bool) *Restorer {
+	return &Restorer{
+		etherpad:    ep,
+		importer:    importer.NewImporter(ep, force, dryRun),
+		dryRun:      dryRun,
+		Concurrency: 4,
+	}
+}
+
+// ReadManifest returns the manifest of the backup archive.
+func ReadManifest(archive string) (*Manifest, error) {
+	var manifest *Manifest
+	err := readArchive(archive, func(name string, r io.Reader) error {
+		if name != ManifestFile {
+			return nil
+		}
+		manifest = &Manifest{}
+		return json.NewDecoder(r).Decode(manifest)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if manifest == nil {
+		return nil, errors.New("manifest is missing")
+	}
+	if manifest.Version > Version {
+		return nil, fmt.Errorf("unsupported backup version: %d", manifest.Version)
+	}
+
+	return manifest, nil
+}
+
+// Restore imports the pads of the backup archive. The checksums of the pads are verified before the import.
+func (r *Restorer) Restore(archive string) (Result, error) {
+	var result Result
+
+	manifest, err := ReadManifest(archive)
+	if err != nil {
+		return result, err
+	}
+
+	done, err := readProgress(r.ProgressFile)
+	if err != nil {
+		return result, err
+	}
+
+	entries := make(map[string]Entry)
+	for _, entry := range manifest.Pads {
+		if entry.Error != "" || (r.Filter != nil && !r.Filter.Match(entry.Pad)) {
+			continue
+		}
+		if done[entry.Pad] {
+			result.Skipped++
+			continue
+		}
+		entries[entry.File] = entry
+	}
+
+	pads, err := r.etherpad.ListAllPads()
+	if err != nil {
+		return result, err
+	}
+	existing := make(map[string]bool)
+	for _, pad := range pads {
+		existing[pad] = true
+	}
+
+	var progress io.Writer = io.Discard
+	if r.ProgressFile != "" && !r.dryRun {
+		f, err := os.OpenFile(r.ProgressFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return result, err
+		}
+		defer f.Close()
+		progress = f
+	}
+
+	var mu sync.Mutex
+	in := make(chan job)
+	go func() {
+		defer close(in)
+		err = readArchive(archive, func(name string, reader io.Reader) error {
+			entry, ok := entries[name]
+			if !ok {
+				return nil
+			}
+			delete(entries, name)
+
+			data, err := io.ReadAll(reader)
+			if err != nil {
+				return err
+			}
+
+			sum := sha256.Sum256(data)
+			if hex.EncodeToString(sum[:]) != entry.SHA256 {
+				log.WithField("pad", entry.Pad).Error("checksum mismatch")
+				mu.Lock()
+				result.Failed++
+				mu.Unlock()
+				return nil
+			}
+
+			in <- job{entry: entry, data: data}
+			return nil
+		})
+	}()
+
+	helper.Consume(r.Concurrency, in, func(j job) {
+		err := r.importer.ImportData(j.entry.Pad, path.Base(j.entry.File), j.data, existing[j.entry.Pad])
+
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			log.WithError(err).WithField("pad", j.entry.Pad).Error("failed to restore pad")
+			result.Failed++
+			return
+		}
+		result.Restored++
+		if _, err = fmt.Fprintln(progress, j.entry.Pad); err != nil {
+			log.WithError(err).WithField("pad", j.entry.Pad).Error("failed to write progress")
+		}
+	})
+
+	for _, entry := range entries {
+		log.WithField("pad", entry.Pad).Error("pad is missing in the archive")
+		result.Failed++
+	}
+
+	return result, err
+}
+
+// readProgress returns the pads which are listed in the progress file. The file is optional.
+func readProgress(file string) (map[string]bool, error) {
+	done := make(map[string]bool)
+	if file == "" {
+		return done, nil
+	}
+
+	f, err := os.Open(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return done, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if pad := strings.TrimSpace(scanner.Text()); pad != "" {
+			done[pad] = true
+		}
+	}
+
+	return done, scanner.Err()
+}
+
+// readArchive calls the function for every file in the tar.gz archive.
+func readArchive(archive string, fn func(name string, r io.Reader) error) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err = fn(header.Name, tr); err != nil {
+			return err
+		}
+	}
+}
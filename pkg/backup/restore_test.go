@@ -0,0 +1,81 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/etherpadtest"
+	"github.com/systemli/etherpad-toolkit/pkg/export"
+	"github.com/systemli/etherpad-toolkit/pkg/helper"
+)
+
+func TestRestorer_Restore(t *testing.T) {
+	fake := newFake()
+	ts := etherpadtest.NewServer(fake)
+	defer ts.Close()
+
+	archive := createBackup(t, ts.URL, []string{"pad1", "pad2-keep", "pad3-keep"})
+	progress := filepath.Join(t.TempDir(), "progress")
+
+	restorer := NewRestorer(pkg.NewEtherpadClient(ts.URL, ""), false, false)
+	restorer.ProgressFile = progress
+	restorer.Filter = &helper.PadFilter{Suffixes: []string{"keep"}}
+
+	result, err := restorer.Restore(archive)
+	assert.Nil(t, err)
+	assert.Equal(t, Result{Restored: 2}, result)
+	assert.ElementsMatch(t, []string{"import pad2-keep", "import pad3-keep"}, writes(fake))
+
+	// A second run skips the restored pads, pad1 exists and is not overwritten without force.
+	fake.Reset()
+	restorer.Filter = nil
+
+	result, err = restorer.Restore(archive)
+	assert.Nil(t, err)
+	assert.Equal(t, Result{Skipped: 2, Failed: 1}, result)
+	assert.Empty(t, writes(fake))
+
+	restorer = NewRestorer(pkg.NewEtherpadClient(ts.URL, ""), true, false)
+	restorer.ProgressFile = progress
+
+	result, err = restorer.Restore(archive)
+	assert.Nil(t, err)
+	assert.Equal(t, Result{Restored: 1, Skipped: 2}, result)
+	assert.Equal(t, []string{"import pad1-import-tmp-*", "movePad pad1-import-tmp-* pad1"}, writes(fake))
+
+	b, err := os.ReadFile(progress)
+	assert.Nil(t, err)
+	assert.Contains(t, string(b), "pad1\n")
+}
+
+func TestRestorer_Restore_ChecksumMismatch(t *testing.T) {
+	fake := newFake()
+	ts := etherpadtest.NewServer(fake)
+	defer ts.Close()
+
+	archive := filepath.Join(t.TempDir(), "backup.tar.gz")
+	target, err := export.NewArchiveTarget(archive)
+	assert.Nil(t, err)
+	assert.Nil(t, target.Write("pads/pad2.etherpad", []byte("{}")))
+	assert.Nil(t, target.Write(ManifestFile, []byte(`{"version": 1, "pads": [{"pad": "pad2", "file": "pads/pad2.etherpad", "sha256": "invalid"}, {"pad": "pad3", "file": "pads/pad3.etherpad"}]}`)))
+	assert.Nil(t, target.Close())
+
+	result, err := NewRestorer(pkg.NewEtherpadClient(ts.URL, ""), false, false).Restore(archive)
+	assert.Nil(t, err)
+	assert.Equal(t, Result{Failed: 2}, result)
+	assert.Empty(t, writes(fake))
+}
+
+func TestReadManifest_Version(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "backup.tar.gz")
+	target, err := export.NewArchiveTarget(archive)
+	assert.Nil(t, err)
+	assert.Nil(t, target.Write(ManifestFile, []byte(`{"version": 2}`)))
+	assert.Nil(t, target.Close())
+
+	_, err = ReadManifest(archive)
+	assert.Error(t, err)
+}
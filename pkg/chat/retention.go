@@ -0,0 +1,95 @@
+package chat
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/compact"
+)
+
+type Retention struct {
+	etherpad  *pkg.Etherpad
+	retention time.Duration
+	dryRun    bool
+
+	// ExportDir is the directory where the chat history is stored before it is modified. No export if empty.
+	ExportDir string
+	// Clear removes all chat messages instead of only the messages older than the retention.
+	Clear bool
+}
+
+// NewRetention returns a instance of Retention.
+func NewRetention(ep *pkg.Etherpad, retention time.Duration, dryRun bool) *Retention {
+	return &Retention{
+		etherpad:  ep,
+		retention: retention,
+		dryRun:    dryRun,
+	}
+}
+
+// Apply removes the chat messages of the pad which are older than the retention.
+// The pad is recreated without history, the current text is preserved.
+// Returns true if the pad was recreated.
+func (r *Retention) Apply(pad string) (bool, error) {
+	messages, err := r.etherpad.GetChatHistory(pad)
+	if err != nil {
+		return false, err
+	}
+
+	keep := r.Filter(messages, time.Now())
+	if len(keep) == len(messages) {
+		return false, nil
+	}
+
+	log.WithFields(log.Fields{"pad": pad, "messages": len(messages), "removed": len(messages) - len(keep)}).Info("Remove Chat Messages")
+	if r.dryRun {
+		return false, nil
+	}
+
+	if r.ExportDir != "" {
+		if err = r.export(pad, messages); err != nil {
+			return false, err
+		}
+	}
+
+	return true, compact.Recreate(r.etherpad, pad, keep)
+}
+
+// Filter returns the messages which are newer than the retention. Returns no messages if Clear is set and at least
+// one message is older than the retention.
+func (r *Retention) Filter(messages []pkg.ChatMessage, now time.Time) []pkg.ChatMessage {
+	limit := now.Add(-r.retention).UnixMilli()
+	keep := make([]pkg.ChatMessage, 0, len(messages))
+	for _, message := range messages {
+		if message.Time >= limit {
+			keep = append(keep, message)
+		}
+	}
+
+	if r.Clear && len(keep) != len(messages) {
+		return []pkg.ChatMessage{}
+	}
+
+	return keep
+}
+
+func (r *Retention) export(pad string, messages []pkg.ChatMessage) error {
+	if err := os.MkdirAll(r.ExportDir, 0o700); err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(messages, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%d.json", url.PathEscape(pad), time.Now().Unix())
+
+	return os.WriteFile(filepath.Join(r.ExportDir, name), b, 0o600)
+}
@@ -0,0 +1,81 @@
+package chat
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/systemli/etherpad-toolkit/pkg"
+)
+
+func TestRetention_Filter(t *testing.T) {
+	now := time.Now()
+	messages := []pkg.ChatMessage{
+		{Text: "old", Time: now.Add(-48 * time.Hour).UnixMilli()},
+		{Text: "new", Time: now.Add(-1 * time.Hour).UnixMilli()},
+	}
+
+	retention := NewRetention(pkg.NewEtherpadClient("", ""), 24*time.Hour, false)
+	assert.Equal(t, []pkg.ChatMessage{messages[1]}, retention.Filter(messages, now))
+	assert.Equal(t, []pkg.ChatMessage{messages[1]}, retention.Filter(messages[1:], now))
+
+	retention.Clear = true
+	assert.Empty(t, retention.Filter(messages, now))
+	assert.Equal(t, []pkg.ChatMessage{messages[1]}, retention.Filter(messages[1:], now))
+}
+
+func TestRetention_Apply(t *testing.T) {
+	var calls []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		calls = append(calls, method)
+
+		w.WriteHeader(http.StatusOK)
+		if method == "getChatHistory" {
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"messages": [{"text":"foo","userId":"a.foo","time":1359199533759,"userName":"test"}]}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": null}`))
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	retention := NewRetention(pkg.NewEtherpadClient(ts.URL, ""), 24*time.Hour, false)
+	retention.ExportDir = dir
+
+	recreated, err := retention.Apply("pad")
+	assert.Nil(t, err)
+	assert.True(t, recreated)
+	assert.Equal(t, []string{"getChatHistory", "copyPadWithoutHistory", "movePad"}, calls)
+
+	files, err := filepath.Glob(filepath.Join(dir, "pad-*.json"))
+	assert.Nil(t, err)
+	assert.Len(t, files, 1)
+
+	b, err := os.ReadFile(files[0])
+	assert.Nil(t, err)
+	assert.Contains(t, string(b), `"text": "foo"`)
+}
+
+func TestRetention_Apply_DryRun(t *testing.T) {
+	var calls []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"messages": [{"text":"foo","userId":"a.foo","time":1359199533759,"userName":"test"}]}}`))
+	}))
+	defer ts.Close()
+
+	retention := NewRetention(pkg.NewEtherpadClient(ts.URL, ""), 24*time.Hour, true)
+
+	recreated, err := retention.Apply("pad")
+	assert.Nil(t, err)
+	assert.False(t, recreated)
+	assert.Equal(t, []string{"getChatHistory"}, calls)
+}
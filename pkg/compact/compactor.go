@@ -0,0 +1,143 @@
+package compact
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/helper"
+)
+
+const (
+	temporarySuffix = "compact-tmp"
+	backupSuffix    = "backup"
+)
+
+type Compactor struct {
+	etherpad  *pkg.Etherpad
+	threshold int
+	dryRun    bool
+
+	// Suffixes limits the compaction to pads with one of the suffixes. All pads are compacted if empty.
+	Suffixes []string
+	// KeepChat restores the chat messages after the history was removed.
+	KeepChat bool
+	// Backup creates a full copy of the pad with the ID "<pad>-<unix timestamp>-backup" before the compaction. The
+	// copy ends with the suffix "backup", so it can be kept with an expiration rule for the suffix.
+	Backup bool
+}
+
+// NewCompactor returns a instance of Compactor.
+func NewCompactor(ep *pkg.Etherpad, threshold int, dryRun bool) *Compactor {
+	return &Compactor{
+		etherpad:  ep,
+		threshold: threshold,
+		dryRun:    dryRun,
+	}
+}
+
+// Applies returns true if the pad matches the suffixes and has more revisions than the threshold.
+func (c *Compactor) Applies(pad string, revisions int) bool {
+	if revisions <= c.threshold {
+		return false
+	}
+
+	if len(c.Suffixes) == 0 {
+		return true
+	}
+
+	for _, suffix := range c.Suffixes {
+		if strings.HasSuffix(pad, fmt.Sprintf("-%s", suffix)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CompactPads compacts all given pads which exceed the revision threshold.
+func (c *Compactor) CompactPads(pads []string, concurrency int) {
+	log.WithFields(log.Fields{"count": len(pads), "threshold": c.threshold, "concurrency": concurrency}).Info("start compaction")
+	start := time.Now()
+	helper.ForEach(concurrency, len(pads), func(i int) {
+		c.compactPad(pads[i])
+	})
+
+	log.WithFields(log.Fields{"took": time.Since(start), "processed": len(pads)}).Info("finished compaction")
+}
+
+// Compact replaces the pad with a copy without history. The pad ID and the current text are preserved.
+func (c *Compactor) Compact(pad string) error {
+	log.WithField("pad", pad).Info("Compact Pad")
+	if c.dryRun {
+		return nil
+	}
+
+	var messages []pkg.ChatMessage
+	if c.KeepChat {
+		var err error
+		messages, err = c.etherpad.GetChatHistory(pad)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.Backup {
+		backup := fmt.Sprintf("%s-%d-%s", pad, time.Now().Unix(), backupSuffix)
+		if err := c.etherpad.CopyPad(pad, backup, false); err != nil {
+			return err
+		}
+		log.WithFields(log.Fields{"pad": pad, "backup": backup}).Info("created backup")
+	}
+
+	return Recreate(c.etherpad, pad, messages)
+}
+
+// Recreate replaces the pad with a copy without history and chat. The given chat messages are appended to the new pad.
+// The copy has a random temporary ID and is never written over an existing pad. It is removed if the pad can not be
+// replaced.
+func Recreate(ep *pkg.Etherpad, pad string, messages []pkg.ChatMessage) error {
+	tmp, err := helper.TemporaryPadID(pad, temporarySuffix)
+	if err != nil {
+		return err
+	}
+	if err = ep.CopyPadWithoutHistory(pad, tmp, false); err != nil {
+		return err
+	}
+
+	err = func() error {
+		for _, message := range messages {
+			if err := ep.AppendChatMessage(tmp, message); err != nil {
+				return err
+			}
+		}
+
+		return ep.MovePad(tmp, pad, true)
+	}()
+	if err != nil {
+		if deleteErr := ep.DeletePad(tmp); deleteErr != nil {
+			log.WithError(deleteErr).WithField("pad", tmp).Error("failed to remove temporary pad")
+		}
+	}
+
+	return err
+}
+
+// compactPad compacts the pad if it exceeds the revision threshold.
+func (c *Compactor) compactPad(pad string) {
+	revisions, err := c.etherpad.GetRevisionsCount(pad)
+	if err != nil {
+		log.WithError(err).WithField("pad", pad).Error("failed to get revisions count")
+		return
+	}
+
+	if !c.Applies(pad, revisions) {
+		return
+	}
+
+	if err = c.Compact(pad); err != nil {
+		log.WithError(err).WithField("pad", pad).Error("failed to compact pad")
+	}
+}
@@ -0,0 +1,96 @@
+package compact
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/etherpadtest"
+)
+
+func newFake() *etherpadtest.Fake {
+	return &etherpadtest.Fake{Responses: map[string]etherpadtest.Response{
+		"getRevisionsCount": func(r *http.Request) string {
+			if r.FormValue("padID") == "pad-keep" {
+				return `{"revisions": 5000}`
+			}
+			return `{"revisions": 10}`
+		},
+		"getChatHistory": etherpadtest.Data(`{"messages": [{"text":"foo","userId":"a.foo","time":1359199533759,"userName":"test"}]}`),
+	}}
+}
+
+func TestCompactor_Applies(t *testing.T) {
+	compactor := NewCompactor(pkg.NewEtherpadClient("", ""), 1000, false)
+
+	assert.False(t, compactor.Applies("pad", 1000))
+	assert.True(t, compactor.Applies("pad", 1001))
+
+	compactor.Suffixes = []string{"keep"}
+
+	assert.False(t, compactor.Applies("pad", 1001))
+	assert.True(t, compactor.Applies("pad-keep", 1001))
+}
+
+func TestCompactor_CompactPads(t *testing.T) {
+	fake := newFake()
+	ts := etherpadtest.NewServer(fake)
+	defer ts.Close()
+
+	compactor := NewCompactor(pkg.NewEtherpadClient(ts.URL, ""), 1000, false)
+	compactor.KeepChat = true
+	compactor.Backup = true
+
+	compactor.CompactPads([]string{"pad-keep"}, 1)
+
+	assert.Regexp(t, `^copyPad pad-keep pad-keep-\d+-backup$`, fake.Calls("copyPad")[0])
+	assert.Equal(t, []string{
+		"getRevisionsCount pad-keep",
+		"getChatHistory pad-keep",
+		"copyPadWithoutHistory pad-keep pad-keep-compact-tmp-*",
+		"appendChatMessage pad-keep-compact-tmp-*",
+		"movePad pad-keep-compact-tmp-* pad-keep",
+	}, fake.Calls("getRevisionsCount", "getChatHistory", "copyPadWithoutHistory", "appendChatMessage", "movePad"))
+}
+
+func TestRecreate_Failed(t *testing.T) {
+	var force string
+	fake := newFake()
+	fake.Errors = map[string]bool{"movePad": true}
+	fake.Responses["copyPadWithoutHistory"] = func(r *http.Request) string {
+		force = r.FormValue("force")
+		return "null"
+	}
+	ts := etherpadtest.NewServer(fake)
+	defer ts.Close()
+
+	err := Recreate(pkg.NewEtherpadClient(ts.URL, ""), "pad", nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, "false", force)
+	assert.Equal(t, []string{"copyPadWithoutHistory pad pad-compact-tmp-*", "movePad pad-compact-tmp-* pad", "deletePad pad-compact-tmp-*"}, fake.Calls())
+}
+
+func TestRecreate_CopyFailed(t *testing.T) {
+	fake := newFake()
+	fake.Errors = map[string]bool{"copyPadWithoutHistory": true}
+	ts := etherpadtest.NewServer(fake)
+	defer ts.Close()
+
+	// an existing pad with the temporary ID is neither overwritten nor deleted
+	err := Recreate(pkg.NewEtherpadClient(ts.URL, ""), "pad", nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, []string{"copyPadWithoutHistory pad pad-compact-tmp-*"}, fake.Calls())
+}
+
+func TestCompactor_CompactPads_DryRun(t *testing.T) {
+	fake := newFake()
+	ts := etherpadtest.NewServer(fake)
+	defer ts.Close()
+
+	compactor := NewCompactor(pkg.NewEtherpadClient(ts.URL, ""), 1000, true)
+
+	compactor.CompactPads([]string{"pad", "pad-keep"}, 2)
+
+	assert.ElementsMatch(t, []string{"getRevisionsCount pad", "getRevisionsCount pad-keep"}, fake.Calls())
+}
@@ -0,0 +1,220 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	Equal = iota
+	Delete
+	Insert
+)
+
+// Line is a line of the diff.
+type Line struct {
+	Kind int
+	Text string
+}
+
+// Lines returns the shortest edit script from a to b with the Myers algorithm.
+func Lines(a, b []string) []Line {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var lines []Line
+	for _, text := range a[:prefix] {
+		lines = append(lines, Line{Kind: Equal, Text: text})
+	}
+	lines = append(lines, myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, text := range a[len(a)-suffix:] {
+		lines = append(lines, Line{Kind: Equal, Text: text})
+	}
+
+	return lines
+}
+
+func myers(a, b []string) []Line {
+	n, m := len(a), len(b)
+	max := n + m
+	offset := max + 1
+	v := make([]int, 2*max+3)
+
+	// trace contains the furthest reaching paths of the diagonals -d to d before every step d
+	var trace [][]int
+	for d := 0; d <= max; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+		done := false
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				done = true
+				break
+			}
+		}
+		if done {
+			break
+		}
+	}
+
+	// walk back from the end and collect the lines in reverse order
+	var reversed []Line
+	x, y := n, m
+	for d := len(trace) - 1; d > 0; d-- {
+		v := trace[d]
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v[k-1+d] < v[k+1+d]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[prevK+d]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			reversed = append(reversed, Line{Kind: Equal, Text: a[x]})
+		}
+		if x == prevX {
+			reversed = append(reversed, Line{Kind: Insert, Text: b[prevY]})
+		} else {
+			reversed = append(reversed, Line{Kind: Delete, Text: a[prevX]})
+		}
+		x, y = prevX, prevY
+	}
+	// the lines before the first edit are equal
+	for x > 0 {
+		x--
+		reversed = append(reversed, Line{Kind: Equal, Text: a[x]})
+	}
+
+	lines := make([]Line, len(reversed))
+	for i, line := range reversed {
+		lines[len(reversed)-1-i] = line
+	}
+
+	return lines
+}
+
+// Unified returns the changes from a to b in the unified format with the number of context lines around the changes.
+// An empty string is returned if the texts are equal.
+func Unified(a, b, nameA, nameB string, context int) string {
+	lines := Lines(split(a), split(b))
+
+	var out strings.Builder
+	i := 0
+	for i < len(lines) {
+		// find the next change and the hunk around it
+		for i < len(lines) && lines[i].Kind == Equal {
+			i++
+		}
+		if i == len(lines) {
+			break
+		}
+		start := i - context
+		if start < 0 {
+			start = 0
+		}
+		end := i
+		for end < len(lines) {
+			if lines[end].Kind != Equal {
+				end++
+				continue
+			}
+			next := end
+			for next < len(lines) && lines[next].Kind == Equal {
+				next++
+			}
+			if next == len(lines) || next-end > 2*context {
+				end += context
+				if end > next {
+					end = next
+				}
+				break
+			}
+			end = next
+		}
+
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", nameA, nameB)
+		}
+		writeHunk(&out, lines, start, end)
+		i = end
+	}
+
+	return out.String()
+}
+
+func writeHunk(out *strings.Builder, lines []Line, start, end int) {
+	lineA, lineB := 1, 1
+	for _, line := range lines[:start] {
+		if line.Kind != Insert {
+			lineA++
+		}
+		if line.Kind != Delete {
+			lineB++
+		}
+	}
+
+	countA, countB := 0, 0
+	for _, line := range lines[start:end] {
+		if line.Kind != Insert {
+			countA++
+		}
+		if line.Kind != Delete {
+			countB++
+		}
+	}
+
+	fmt.Fprintf(out, "@@ -%s +%s @@\n", hunkRange(lineA, countA), hunkRange(lineB, countB))
+	for _, line := range lines[start:end] {
+		prefix := " "
+		switch line.Kind {
+		case Delete:
+			prefix = "-"
+		case Insert:
+			prefix = "+"
+		}
+		fmt.Fprintf(out, "%s%s\n", prefix, line.Text)
+	}
+}
+
+func hunkRange(line, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", line-1)
+	case 1:
+		return fmt.Sprintf("%d", line)
+	}
+
+	return fmt.Sprintf("%d,%d", line, count)
+}
+
+func split(text string) []string {
+	text = strings.TrimSuffix(text, "\n")
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(text, "\n")
+}
@@ -0,0 +1,109 @@
+package diff
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLines(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	alphabet := []string{"a", "b", "c"}
+	generate := func() []string {
+		lines := make([]string, random.Intn(8))
+		for i := range lines {
+			lines[i] = alphabet[random.Intn(len(alphabet))]
+		}
+		return lines
+	}
+
+	for i := 0; i < 1000; i++ {
+		a, b := generate(), generate()
+		lines := Lines(a, b)
+
+		var gotA, gotB []string
+		changes := 0
+		for _, line := range lines {
+			if line.Kind != Insert {
+				gotA = append(gotA, line.Text)
+			}
+			if line.Kind != Delete {
+				gotB = append(gotB, line.Text)
+			}
+			if line.Kind != Equal {
+				changes++
+			}
+		}
+
+		assert.Equal(t, len(a), len(gotA))
+		assert.Equal(t, len(b), len(gotB))
+		if len(a) > 0 {
+			assert.Equal(t, a, gotA)
+		}
+		if len(b) > 0 {
+			assert.Equal(t, b, gotB)
+		}
+		assert.Equal(t, len(a)+len(b)-2*lcs(a, b), changes, "%v -> %v", a, b)
+	}
+}
+
+func lcs(a, b []string) int {
+	table := make([][]int, len(a)+1)
+	for i := range table {
+		table[i] = make([]int, len(b)+1)
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			switch {
+			case a[i-1] == b[j-1]:
+				table[i][j] = table[i-1][j-1] + 1
+			case table[i-1][j] > table[i][j-1]:
+				table[i][j] = table[i-1][j]
+			default:
+				table[i][j] = table[i][j-1]
+			}
+		}
+	}
+
+	return table[len(a)][len(b)]
+}
+
+func TestUnified(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+	b := "1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n12\n13\n"
+
+	assert.Equal(t, `--- pad@1
++++ pad@2
+@@ -1,6 +1,6 @@
+ 1
+ 2
+-3
++three
+ 4
+ 5
+ 6
+@@ -8,5 +8,5 @@
+ 8
+ 9
+ 10
+-11
+ 12
++13
+`, Unified(a, b, "pad@1", "pad@2", 3))
+
+	assert.Equal(t, `--- a
++++ b
+@@ -2 +2 @@
+-2
++two
+`, Unified("1\n2\n3\n", "1\ntwo\n3\n", "a", "b", 0))
+
+	assert.Equal(t, `--- a
++++ b
+@@ -0,0 +1 @@
++new
+`, Unified("\n", "new\n", "a", "b", 3))
+
+	assert.Empty(t, Unified(a, a, "a", "b", 3))
+}
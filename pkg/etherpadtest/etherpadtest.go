@@ -0,0 +1,160 @@
+// Package etherpadtest provides a fake Etherpad for the tests of the packages which use the API client.
+package etherpadtest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// temporary matches the random part of temporary pad IDs, see helper.TemporaryPadID.
+var temporary = regexp.MustCompile(`(-tmp)-[0-9a-f]{16}\b`)
+
+// Mask replaces the random part of the temporary pad IDs in the call with "*", e.g. "import pad-import-tmp-*".
+func Mask(call string) string {
+	return temporary.ReplaceAllString(call, "$1-*")
+}
+
+// Response returns the JSON data of an API response for the request.
+type Response func(r *http.Request) string
+
+// Data returns a Response which always answers with the JSON data.
+func Data(data string) Response {
+	return func(r *http.Request) string {
+		return data
+	}
+}
+
+// Fake is an Etherpad which records the calls of the API and the import endpoint. The requests are handled one at a
+// time, so the functions of the Fake need no locking.
+type Fake struct {
+	// Responses answers the API methods. Methods without a Response answer with null.
+	Responses map[string]Response
+	// Errors lists the API methods which answer with an error. "import" fails the import endpoint.
+	Errors map[string]bool
+	// Export returns the export of the pad. An empty export answers with 404 Not Found, as all exports if unset.
+	Export func(pad string) string
+	// Import receives the uploaded file of the import endpoint.
+	Import func(pad string, data []byte)
+	// Missing reports the pads which do not exist. Like Etherpad, the API methods except createPad answer with an error
+	// for the padID of a missing pad. No pad is missing if unset.
+	Missing func(pad string) bool
+
+	mu    sync.Mutex
+	calls []string
+}
+
+// NewServer starts a server with the handler of the Fake. The caller must close the server.
+func NewServer(f *Fake) *httptest.Server {
+	return httptest.NewServer(f.Handler())
+}
+
+// Handler returns the handler which serves the API, the export and the import endpoints.
+func (f *Fake) Handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		parts := strings.Split(r.URL.Path, "/")
+		if len(parts) > 3 && parts[1] == "p" {
+			f.file(w, r, parts[2], parts[len(parts)-1])
+			return
+		}
+
+		method := parts[len(parts)-1]
+		call := []string{method}
+		for _, param := range []string{"padID", "sourceID", "destinationID", "rev"} {
+			if value := r.FormValue(param); value != "" {
+				call = append(call, value)
+			}
+		}
+		f.calls = append(f.calls, Mask(strings.Join(call, " ")))
+
+		pad := r.FormValue("padID")
+		if f.Errors[method] || (f.Missing != nil && pad != "" && method != "createPad" && f.Missing(pad)) {
+			_, _ = w.Write([]byte(`{"code": 1, "message":"failed", "data": null}`))
+			return
+		}
+
+		data := "null"
+		if response, ok := f.Responses[method]; ok {
+			data = response(r)
+		}
+		_, _ = fmt.Fprintf(w, `{"code": 0, "message":"ok", "data": %s}`, data)
+	}
+}
+
+func (f *Fake) file(w http.ResponseWriter, r *http.Request, pad, action string) {
+	if action != "import" {
+		var export string
+		if f.Export != nil {
+			export = f.Export(pad)
+		}
+		if export == "" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(export))
+		return
+	}
+
+	f.calls = append(f.calls, Mask("import "+pad))
+	if f.Errors["import"] {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if f.Import != nil {
+		f.Import(pad, data)
+	}
+	_, _ = w.Write([]byte(`{"code": 0, "message":"ok"}`))
+}
+
+// Calls returns the recorded calls of the methods, or all calls if no method is given. A call is recorded as the
+// method followed by the padID, sourceID, destinationID and rev parameters which are set, e.g. "movePad pad-tmp pad".
+// The random part of temporary pad IDs is masked, see Mask.
+func (f *Fake) Calls(methods ...string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var calls []string
+	for _, call := range f.calls {
+		if len(methods) == 0 || contains(methods, strings.SplitN(call, " ", 2)[0]) {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+// Reset discards the recorded calls.
+func (f *Fake) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls = nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
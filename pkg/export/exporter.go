@@ -0,0 +1,157 @@
+package export
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/helper"
+)
+
+const (
+	FormatText     = "txt"
+	FormatHTML     = "html"
+	FormatMarkdown = "markdown"
+	FormatEtherpad = "etherpad"
+
+	// ManifestFile is the name of the manifest in the export.
+	ManifestFile = "manifest.json"
+)
+
+// Formats are the supported export formats.
+var Formats = []string{FormatText, FormatHTML, FormatMarkdown, FormatEtherpad}
+
+var extensions = map[string]string{
+	FormatText:     "txt",
+	FormatHTML:     "html",
+	FormatMarkdown: "md",
+	FormatEtherpad: "etherpad",
+}
+
+// Entry is the result of the export of a single pad.
+type Entry struct {
+	Pad    string `json:"pad"`
+	File   string `json:"file,omitempty"`
+	Size   int    `json:"size"`
+	SHA256 string `json:"sha256,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Manifest lists the exported pads.
+type Manifest struct {
+	ExportedAt time.Time `json:"exportedAt"`
+	Format     string    `json:"format"`
+	Revision   *int      `json:"revision,omitempty"`
+	Pads       []Entry   `json:"pads"`
+}
+
+// Failed returns the number of pads which failed to export.
+func (m *Manifest) Failed() int {
+	failed := 0
+	for _, entry := range m.Pads {
+		if entry.Error != "" {
+			failed++
+		}
+	}
+
+	return failed
+}
+
+type Exporter struct {
+	etherpad *pkg.Etherpad
+	format   string
+
+	// Revision is the revision to export. The latest revision is exported if pkg.LatestRevision.
+	Revision int
+	// Concurrency is the number of pads which are downloaded in parallel.
+	Concurrency int
+}
+
+// NewExporter returns a instance of Exporter for the format.
+func NewExporter(ep *pkg.Etherpad, format string) (*Exporter, error) {
+	if _, ok := extensions[format]; !ok {
+		return nil, fmt.Errorf("unknown format: %s", format)
+	}
+
+	return &Exporter{
+		etherpad:    ep,
+		format:      format,
+		Revision:    pkg.LatestRevision,
+		Concurrency: 4,
+	}, nil
+}
+
+// Export downloads the pads into the target and writes the manifest. Pads which fail to export are listed with the
+// error in the manifest. An error is returned if the target can not be written.
+func (e *Exporter) Export(pads []string, target Target) (*Manifest, error) {
+	manifest := &Manifest{ExportedAt: time.Now(), Format: e.format, Pads: make([]Entry, len(pads))}
+	if e.Revision != pkg.LatestRevision {
+		manifest.Revision = &e.Revision
+	}
+
+	var mu sync.Mutex
+	var targetErr error
+	helper.ForEach(e.Concurrency, len(pads), func(i int) {
+		entry := Entry{Pad: pads[i]}
+		data, err := e.Download(pads[i])
+		if err != nil {
+			log.WithError(err).WithField("pad", pads[i]).Error("failed to export pad")
+			entry.Error = err.Error()
+			manifest.Pads[i] = entry
+			return
+		}
+
+		sum := sha256.Sum256(data)
+		entry.File = FileName(pads[i], e.format)
+		entry.Size = len(data)
+		entry.SHA256 = hex.EncodeToString(sum[:])
+
+		mu.Lock()
+		if err = target.Write(entry.File, data); err != nil && targetErr == nil {
+			targetErr = err
+		}
+		mu.Unlock()
+
+		manifest.Pads[i] = entry
+	})
+
+	if targetErr != nil {
+		return manifest, targetErr
+	}
+
+	b, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return manifest, err
+	}
+
+	return manifest, target.Write(ManifestFile, b)
+}
+
+// Download returns the content of the pad in the format of the Exporter.
+func (e *Exporter) Download(pad string) ([]byte, error) {
+	switch e.format {
+	case FormatText:
+		text, err := e.etherpad.GetTextAtRevision(pad, e.Revision)
+		return []byte(text), err
+	case FormatHTML, FormatMarkdown:
+		html, err := e.etherpad.GetHTMLAtRevision(pad, e.Revision)
+		if err != nil || e.format == FormatHTML {
+			return []byte(html), err
+		}
+		md, err := HTMLToMarkdown(html)
+		return []byte(md), err
+	default:
+		return e.etherpad.ExportPad(pad, e.format, e.Revision)
+	}
+}
+
+// FileName returns the name of the file for the pad in the format. The pad ID is escaped to be a valid file name.
+func FileName(pad, format string) string {
+	return fmt.Sprintf("%s.%s", url.PathEscape(pad), extensions[format])
+}
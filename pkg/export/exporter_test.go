@@ -0,0 +1,97 @@
+package export
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/systemli/etherpad-toolkit/pkg"
+)
+
+func etherpadServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/p/") {
+			if r.URL.Path != "/p/pad1/export/etherpad" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(`{"pad:pad1":{}}`))
+			return
+		}
+
+		method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		if r.URL.Query().Get("padID") != "pad1" {
+			_, _ = w.Write([]byte(`{"code": 1, "message":"padID does not exist", "data": null}`))
+			return
+		}
+
+		switch method {
+		case "getText":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"text": "Hello rev ` + r.URL.Query().Get("rev") + `"}}`))
+		case "getHTML":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"html": "<html><body><strong>Hello</strong><br></body></html>"}}`))
+		}
+	}))
+}
+
+func TestExporter_Export(t *testing.T) {
+	ts := etherpadServer()
+	defer ts.Close()
+
+	dir := t.TempDir()
+	target, err := NewDirTarget(dir)
+	assert.Nil(t, err)
+
+	exporter, err := NewExporter(pkg.NewEtherpadClient(ts.URL, ""), FormatMarkdown)
+	assert.Nil(t, err)
+
+	manifest, err := exporter.Export([]string{"pad1", "pad2"}, target)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, manifest.Failed())
+	assert.Equal(t, "pad1.md", manifest.Pads[0].File)
+	assert.Equal(t, "error: padID does not exist (code: 1)", manifest.Pads[1].Error)
+
+	b, err := os.ReadFile(filepath.Join(dir, "pad1.md"))
+	assert.Nil(t, err)
+	assert.Equal(t, "**Hello**\n", string(b))
+
+	b, err = os.ReadFile(filepath.Join(dir, ManifestFile))
+	assert.Nil(t, err)
+	var written Manifest
+	assert.Nil(t, json.Unmarshal(b, &written))
+	assert.Equal(t, manifest.Pads, written.Pads)
+	assert.Nil(t, written.Revision)
+}
+
+func TestExporter_Download(t *testing.T) {
+	ts := etherpadServer()
+	defer ts.Close()
+
+	exporter, err := NewExporter(pkg.NewEtherpadClient(ts.URL, ""), FormatText)
+	assert.Nil(t, err)
+	exporter.Revision = 3
+
+	b, err := exporter.Download("pad1")
+	assert.Nil(t, err)
+	assert.Equal(t, "Hello rev 3", string(b))
+
+	exporter, err = NewExporter(pkg.NewEtherpadClient(ts.URL, ""), FormatEtherpad)
+	assert.Nil(t, err)
+
+	b, err = exporter.Download("pad1")
+	assert.Nil(t, err)
+	assert.Equal(t, `{"pad:pad1":{}}`, string(b))
+
+	_, err = NewExporter(pkg.NewEtherpadClient(ts.URL, ""), "pdf")
+	assert.Error(t, err)
+}
+
+func TestFileName(t *testing.T) {
+	assert.Equal(t, "pad.txt", FileName("pad", FormatText))
+	assert.Equal(t, "g.abc$pad%2Fsub.md", FileName("g.abc$pad/sub", FormatMarkdown))
+}
@@ -0,0 +1,162 @@
+package export
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	listItem   = regexp.MustCompile(`^\s*(-|\d+\.) `)
+	blankLines = regexp.MustCompile(`\n{3,}`)
+	escaper    = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `_`, `\_`, "`", "\\`", "\u00a0", " ")
+)
+
+type list struct {
+	ordered bool
+	index   int
+	width   int
+}
+
+// HTMLToMarkdown converts the HTML of a pad as returned by getHTML to Markdown. Lines of the pad are kept as lines,
+// headings, lists, links and the formatting bold, italic, strikethrough and code are converted.
+func HTMLToMarkdown(s string) (string, error) {
+	doc, err := html.Parse(strings.NewReader(s))
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	convert(&b, doc, nil)
+
+	return cleanup(b.String()), nil
+}
+
+func convert(b *strings.Builder, n *html.Node, lists []*list) {
+	if n.Type == html.TextNode {
+		b.WriteString(escaper.Replace(n.Data))
+		return
+	}
+
+	if n.Type != html.ElementNode {
+		children(b, n, lists)
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.Head, atom.Script, atom.Style:
+	case atom.Br:
+		b.WriteString("\n")
+	case atom.Strong, atom.B:
+		wrap(b, n, lists, "**")
+	case atom.Em, atom.I:
+		wrap(b, n, lists, "_")
+	case atom.S, atom.Del, atom.Strike:
+		wrap(b, n, lists, "~~")
+	case atom.Code:
+		wrap(b, n, lists, "`")
+	case atom.A:
+		var inner strings.Builder
+		children(&inner, n, lists)
+		if href := attr(n, "href"); href != "" {
+			fmt.Fprintf(b, "[%s](%s)", inner.String(), href)
+		} else {
+			b.WriteString(inner.String())
+		}
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		newline(b)
+		b.WriteString(strings.Repeat("#", int(n.Data[1]-'0')) + " ")
+		children(b, n, lists)
+		b.WriteString("\n")
+	case atom.Ul, atom.Ol:
+		l := &list{ordered: n.DataAtom == atom.Ol, index: 1}
+		if start, err := strconv.Atoi(attr(n, "start")); err == nil {
+			l.index = start
+		}
+		newline(b)
+		children(b, n, append(lists, l))
+		// A blank line prevents that the following text continues the last list item.
+		if len(lists) == 0 {
+			newline(b)
+			b.WriteString("\n")
+		}
+	case atom.Li:
+		newline(b)
+		if len(lists) > 0 {
+			for _, parent := range lists[:len(lists)-1] {
+				b.WriteString(strings.Repeat(" ", parent.width))
+			}
+			l := lists[len(lists)-1]
+			marker := "- "
+			if l.ordered {
+				marker = fmt.Sprintf("%d. ", l.index)
+				l.index++
+			}
+			l.width = len(marker)
+			b.WriteString(marker)
+		}
+		children(b, n, lists)
+		newline(b)
+	default:
+		children(b, n, lists)
+	}
+}
+
+func children(b *strings.Builder, n *html.Node, lists []*list) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		convert(b, c, lists)
+	}
+}
+
+// wrap surrounds the content with the marker. Whitespace is moved outside, otherwise the marker is not recognized.
+func wrap(b *strings.Builder, n *html.Node, lists []*list, marker string) {
+	var inner strings.Builder
+	children(&inner, n, lists)
+
+	content := inner.String()
+	trimmed := strings.TrimSpace(content)
+	if trimmed == "" {
+		b.WriteString(content)
+		return
+	}
+
+	start := strings.Index(content, trimmed)
+	b.WriteString(content[:start] + marker + trimmed + marker + content[start+len(trimmed):])
+}
+
+func newline(b *strings.Builder) {
+	if b.Len() > 0 && !strings.HasSuffix(b.String(), "\n") {
+		b.WriteString("\n")
+	}
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+
+	return ""
+}
+
+// cleanup adds hard line breaks between consecutive lines of text and removes superfluous blank lines.
+func cleanup(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	for i := 0; i < len(lines)-1; i++ {
+		lines[i] = strings.TrimRight(lines[i], " ")
+		if isText(lines[i]) && isText(lines[i+1]) {
+			lines[i] += "  "
+		}
+	}
+
+	return blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n") + "\n"
+}
+
+func isText(line string) bool {
+	return strings.TrimSpace(line) != "" && !strings.HasPrefix(line, "#") && !listItem.MatchString(line)
+}
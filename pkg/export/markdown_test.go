@@ -0,0 +1,30 @@
+package export
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTMLToMarkdown(t *testing.T) {
+	html := `<!DOCTYPE HTML><html><body><h1>Title</h1>Line with <strong>bold </strong>and <em>italic</em> text<br>` +
+		`Second line with a <a href="https://example.org">link</a> and 2*2<br><br>` +
+		`<ul class="bullet"><li>one</li><li>two<ul class="bullet"><li>nested</li></ul></li></ul>` +
+		`<ol start="1" class="number"><li>first</li><li><s>second</s></li></ol>` +
+		`After&nbsp;the list<br></body></html>`
+
+	md, err := HTMLToMarkdown(html)
+	assert.Nil(t, err)
+	assert.Equal(t, "# Title\n"+
+		"Line with **bold** and _italic_ text  \n"+
+		"Second line with a [link](https://example.org) and 2\\*2\n"+
+		"\n"+
+		"- one\n"+
+		"- two\n"+
+		"  - nested\n"+
+		"\n"+
+		"1. first\n"+
+		"2. ~~second~~\n"+
+		"\n"+
+		"After the list\n", md)
+}
@@ -0,0 +1,118 @@
+package export
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Target stores the exported files.
+type Target interface {
+	Write(name string, data []byte) error
+	Close() error
+}
+
+type dirTarget struct {
+	dir string
+}
+
+// NewDirTarget returns a Target which writes the files into the directory. The directory is created if it does not
+// exist.
+func NewDirTarget(dir string) (Target, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &dirTarget{dir: dir}, nil
+}
+
+func (t *dirTarget) Write(name string, data []byte) error {
+	return os.WriteFile(filepath.Join(t.dir, name), data, 0o644)
+}
+
+func (t *dirTarget) Close() error {
+	return nil
+}
+
+type zipTarget struct {
+	file   *os.File
+	writer *zip.Writer
+}
+
+type tarTarget struct {
+	file   *os.File
+	gzip   *gzip.Writer
+	writer *tar.Writer
+}
+
+// NewArchiveTarget returns a Target which writes the files into a archive. The type of the archive is chosen by the
+// extension of the path: ".zip", ".tar.gz" or ".tgz".
+func NewArchiveTarget(path string) (Target, error) {
+	var create func(f *os.File) Target
+	switch {
+	case strings.HasSuffix(path, ".zip"):
+		create = func(f *os.File) Target {
+			return &zipTarget{file: f, writer: zip.NewWriter(f)}
+		}
+	case strings.HasSuffix(path, ".tar.gz"), strings.HasSuffix(path, ".tgz"):
+		create = func(f *os.File) Target {
+			gz := gzip.NewWriter(f)
+			return &tarTarget{file: f, gzip: gz, writer: tar.NewWriter(gz)}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported archive type: %s", path)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return create(f), nil
+}
+
+func (t *zipTarget) Write(name string, data []byte) error {
+	w, err := t.writer.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+
+	return err
+}
+
+func (t *zipTarget) Close() error {
+	return closeAll(t.writer, t.file)
+}
+
+func (t *tarTarget) Write(name string, data []byte) error {
+	header := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), ModTime: time.Now()}
+	if err := t.writer.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := t.writer.Write(data)
+
+	return err
+}
+
+func (t *tarTarget) Close() error {
+	return closeAll(t.writer, t.gzip, t.file)
+}
+
+// closeAll closes all closers in order and returns the first error.
+func closeAll(closers ...io.Closer) error {
+	var first error
+	for _, c := range closers {
+		if err := c.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+
+	return first
+}
@@ -0,0 +1,53 @@
+package export
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewArchiveTarget_Zip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "export.zip")
+	target, err := NewArchiveTarget(path)
+	assert.Nil(t, err)
+	assert.Nil(t, target.Write("pad.txt", []byte("Hello")))
+	assert.Nil(t, target.Close())
+
+	r, err := zip.OpenReader(path)
+	assert.Nil(t, err)
+	defer r.Close()
+	assert.Len(t, r.File, 1)
+	assert.Equal(t, "pad.txt", r.File[0].Name)
+}
+
+func TestNewArchiveTarget_TarGz(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "export.tar.gz")
+	target, err := NewArchiveTarget(path)
+	assert.Nil(t, err)
+	assert.Nil(t, target.Write("pad.txt", []byte("Hello")))
+	assert.Nil(t, target.Close())
+
+	f, err := os.Open(path)
+	assert.Nil(t, err)
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	assert.Nil(t, err)
+	tr := tar.NewReader(gz)
+	header, err := tr.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, "pad.txt", header.Name)
+	b, err := io.ReadAll(tr)
+	assert.Nil(t, err)
+	assert.Equal(t, "Hello", string(b))
+}
+
+func TestNewArchiveTarget_Unsupported(t *testing.T) {
+	_, err := NewArchiveTarget(filepath.Join(t.TempDir(), "export.rar"))
+	assert.Error(t, err)
+}
@@ -9,12 +9,18 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-const DefaultSuffix = "default"
+const (
+	DefaultSuffix = "default"
+	// EmptyKey is the reserved key for the expiration of pads without meaningful content. It starts with a colon, so it
+	// can not be a pad suffix.
+	EmptyKey = ":empty"
+)
 
 type PadExpiration map[string]time.Duration
 
 // ParsePadExpiration splits a string with format "default:30d,temp:24h,keep:365d" and returns a PadExpiration type.
-// The key "default:<duration>" is mandatory in the input string.
+// The key "default:<duration>" is mandatory in the input string. The optional key ":empty:<duration>" defines the
+// expiration for pads which contain only whitespace or the default pad text.
 func ParsePadExpiration(s string) (PadExpiration, error) {
 	exp := make(PadExpiration)
 
@@ -23,18 +29,18 @@ func ParsePadExpiration(s string) (PadExpiration, error) {
 	}
 
 	for _, str := range strings.Split(s, ",") {
-		split := strings.Split(str, ":")
-		if len(split) != 2 {
+		i := strings.LastIndex(str, ":")
+		if i < 1 || (strings.Contains(str[:i], ":") && str[:i] != EmptyKey) {
 			log.WithField("string", str).Error("string is not valid")
 			continue
 		}
-		duration, err := time.ParseDuration(split[1])
+		duration, err := time.ParseDuration(str[i+1:])
 		if err != nil {
-			log.WithError(err).WithField("duration", split[1]).Error("unable to parse the duration")
+			log.WithError(err).WithField("duration", str[i+1:]).Error("unable to parse the duration")
 			continue
 		}
 
-		exp[split[0]] = duration
+		exp[str[:i]] = duration
 	}
 
 	if _, ok := exp[DefaultSuffix]; !ok {
@@ -46,20 +52,51 @@ func ParsePadExpiration(s string) (PadExpiration, error) {
 
 // GetDuration tries to get the Duration by pad name, returns the default duration if no suffix matches.
 func (pe *PadExpiration) GetDuration(pad string) time.Duration {
-	for suffix, duration := range *pe {
+	return -(*pe)[pe.GetRule(pad)]
+}
+
+// GetRule returns the suffix which applies to the pad, DefaultSuffix if no suffix matches.
+func (pe *PadExpiration) GetRule(pad string) string {
+	for suffix := range *pe {
+		if suffix == EmptyKey || suffix == DefaultSuffix {
+			continue
+		}
 		if strings.HasSuffix(pad, fmt.Sprintf("-%s", suffix)) {
-			return -duration
+			return suffix
 		}
 	}
 
-	return -(*pe)[DefaultSuffix]
+	return DefaultSuffix
+}
+
+// IsExpired returns true if the pad was not edited within its expiration duration or has no revisions.
+func (pe *PadExpiration) IsExpired(pad string, lastEdited time.Time, revisions int, now time.Time) bool {
+	return lastEdited.Before(now.Add(pe.GetDuration(pad))) || revisions == 0
+}
+
+// GetEmptyDuration returns the negative duration for empty pads and whether the empty pad check is configured.
+func (pe *PadExpiration) GetEmptyDuration() (time.Duration, bool) {
+	duration, ok := (*pe)[EmptyKey]
+
+	return -duration, ok
+}
+
+// IsEmptyText returns true if the text contains only whitespace or equals the default pad text.
+// Whitespace differences are ignored in the comparison with the default text.
+func IsEmptyText(text, defaultText string) bool {
+	normalized := strings.Join(strings.Fields(text), " ")
+	if normalized == "" {
+		return true
+	}
+
+	return defaultText != "" && normalized == strings.Join(strings.Fields(defaultText), " ")
 }
 
 // GroupPadsByExpiration sorts pads for the given expiration and returns a map with string keys and string slices.
 func GroupPadsByExpiration(pads []string, expiration PadExpiration) map[string][]string {
 	var suffixes []string
 	for suffix := range expiration {
-		if suffix == DefaultSuffix {
+		if suffix == DefaultSuffix || suffix == EmptyKey {
 			continue
 		}
 		suffixes = append(suffixes, suffix)
@@ -51,6 +51,23 @@ func TestPadExpiration_GetDuration(t *testing.T) {
 	assert.Equal(t, "-10m0s", dur.String())
 }
 
+func TestPadExpiration_GetRule(t *testing.T) {
+	exp, err := ParsePadExpiration("default:24h,temp:10m,:empty:1h")
+	assert.Nil(t, err)
+
+	assert.Equal(t, DefaultSuffix, exp.GetRule("pad"))
+	assert.Equal(t, "temp", exp.GetRule("pad-temp"))
+	assert.Equal(t, DefaultSuffix, exp.GetRule("pad-empty"))
+
+	// the suffix "empty" is a regular rule
+	exp, err = ParsePadExpiration("default:24h,empty:10m")
+	assert.Nil(t, err)
+
+	assert.Equal(t, "empty", exp.GetRule("pad-empty"))
+	_, ok := exp.GetEmptyDuration()
+	assert.False(t, ok)
+}
+
 func TestGroupPadsByExpiration(t *testing.T) {
 	s := "default:720h,temp:24h,keep:262800h"
 	pads := []string{"pad", "pad2", "pad-keep", "pad-temp"}
@@ -75,3 +92,45 @@ func TestGroupPadsByExpiration(t *testing.T) {
 	assert.Equal(t, []string{"pad-keep"}, sorted["keep"])
 	assert.Equal(t, []string{"pad-temp"}, sorted["temp"])
 }
+
+func TestPadExpiration_IsExpired(t *testing.T) {
+	exp, err := ParsePadExpiration("default:24h,temp:1h")
+	assert.Nil(t, err)
+
+	now := time.Now()
+	assert.False(t, exp.IsExpired("pad", now.Add(-2*time.Hour), 1, now))
+	assert.True(t, exp.IsExpired("pad", now.Add(-2*time.Hour), 0, now))
+	assert.True(t, exp.IsExpired("pad", now.Add(-25*time.Hour), 1, now))
+	assert.True(t, exp.IsExpired("pad-temp", now.Add(-2*time.Hour), 1, now))
+}
+
+func TestPadExpiration_GetEmptyDuration(t *testing.T) {
+	exp, err := ParsePadExpiration("default:24h")
+	assert.Nil(t, err)
+
+	_, ok := exp.GetEmptyDuration()
+	assert.False(t, ok)
+
+	exp, err = ParsePadExpiration("default:24h,:empty:1h")
+	assert.Nil(t, err)
+
+	dur, ok := exp.GetEmptyDuration()
+	assert.True(t, ok)
+	assert.Equal(t, "-1h0m0s", dur.String())
+
+	dur = exp.GetDuration("pad-empty")
+	assert.Equal(t, "-24h0m0s", dur.String())
+
+	sorted := GroupPadsByExpiration([]string{"pad", "pad-empty"}, exp)
+	assert.Equal(t, []string{"pad", "pad-empty"}, sorted[DefaultSuffix])
+}
+
+func TestIsEmptyText(t *testing.T) {
+	defaultText := "Welcome to Etherpad!\n\nThis pad text is synchronized as you type.\n"
+
+	assert.True(t, IsEmptyText("", defaultText))
+	assert.True(t, IsEmptyText(" \n\t\n", defaultText))
+	assert.True(t, IsEmptyText("Welcome to Etherpad!\nThis pad text is synchronized as you type.\n\n", defaultText))
+	assert.False(t, IsEmptyText("Welcome to Etherpad!\n", defaultText))
+	assert.False(t, IsEmptyText("Hello World\n", ""))
+}
@@ -0,0 +1,76 @@
+package helper
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// PadFilter selects pads by their ID. All configured conditions must match, an empty PadFilter matches every pad.
+type PadFilter struct {
+	// Prefixes matches pads which start with one of the prefixes.
+	Prefixes []string
+	// Suffixes matches pads which end with "-<suffix>" for one of the suffixes, as in GroupPadsBySuffixes.
+	Suffixes []string
+	// Regex matches pads which match the regular expression.
+	Regex *regexp.Regexp
+	// Group matches the pads of a group, e.g. "g.s8oes9dhwrvt0zif" for "g.s8oes9dhwrvt0zif$pad".
+	Group string
+}
+
+// NewPadFilter returns a PadFilter and compiles the regular expression if it is not empty.
+func NewPadFilter(prefixes, suffixes []string, regex, group string) (*PadFilter, error) {
+	filter := &PadFilter{Prefixes: prefixes, Suffixes: suffixes, Group: group}
+	if regex != "" {
+		re, err := regexp.Compile(regex)
+		if err != nil {
+			return nil, err
+		}
+		filter.Regex = re
+	}
+
+	return filter, nil
+}
+
+// Match returns true if the pad matches all configured conditions.
+func (f *PadFilter) Match(pad string) bool {
+	if len(f.Prefixes) > 0 && !hasAny(pad, f.Prefixes, strings.HasPrefix, "%s") {
+		return false
+	}
+
+	if len(f.Suffixes) > 0 && !hasAny(pad, f.Suffixes, strings.HasSuffix, "-%s") {
+		return false
+	}
+
+	if f.Regex != nil && !f.Regex.MatchString(pad) {
+		return false
+	}
+
+	if f.Group != "" && !strings.HasPrefix(pad, fmt.Sprintf("%s$", f.Group)) {
+		return false
+	}
+
+	return true
+}
+
+// Apply returns the pads which match the filter.
+func (f *PadFilter) Apply(pads []string) []string {
+	var filtered []string
+	for _, pad := range pads {
+		if f.Match(pad) {
+			filtered = append(filtered, pad)
+		}
+	}
+
+	return filtered
+}
+
+func hasAny(pad string, values []string, fn func(s, v string) bool, format string) bool {
+	for _, value := range values {
+		if fn(pad, fmt.Sprintf(format, value)) {
+			return true
+		}
+	}
+
+	return false
+}
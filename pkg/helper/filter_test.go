@@ -0,0 +1,34 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPadFilter_Apply(t *testing.T) {
+	pads := []string{"pad1", "pad2-keep", "pad3-temp", "test-keep", "g.abc$pad4", "g.def$pad5-keep"}
+
+	filter, err := NewPadFilter(nil, nil, "", "")
+	assert.Nil(t, err)
+	assert.Equal(t, pads, filter.Apply(pads))
+
+	filter, err = NewPadFilter(nil, []string{"keep"}, "", "")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"pad2-keep", "test-keep", "g.def$pad5-keep"}, filter.Apply(pads))
+
+	filter, err = NewPadFilter([]string{"pad"}, []string{"keep", "temp"}, "", "")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"pad2-keep", "pad3-temp"}, filter.Apply(pads))
+
+	filter, err = NewPadFilter(nil, nil, `^pad\d$`, "")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"pad1"}, filter.Apply(pads))
+
+	filter, err = NewPadFilter(nil, nil, "", "g.def")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"g.def$pad5-keep"}, filter.Apply(pads))
+
+	_, err = NewPadFilter(nil, nil, "(", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,48 @@
+package helper
+
+import "sync"
+
+// ForEach calls fn for the indexes 0 to n-1 with the given number of concurrent workers. A concurrency below 1 is
+// treated as 1. ForEach returns when all calls have returned.
+func ForEach(concurrency, n int, fn func(i int)) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	in := make(chan int)
+	var wg sync.WaitGroup
+	for x := 0; x < concurrency; x++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range in {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		in <- i
+	}
+	close(in)
+	wg.Wait()
+}
+
+// Consume calls fn for the values of the channel with the given number of concurrent workers, e.g. for values which
+// are streamed from a file. Consume returns when the channel is closed and all calls have returned.
+func Consume[T any](concurrency int, in <-chan T, fn func(v T)) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var wg sync.WaitGroup
+	for x := 0; x < concurrency; x++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := range in {
+				fn(v)
+			}
+		}()
+	}
+	wg.Wait()
+}
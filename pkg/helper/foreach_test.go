@@ -0,0 +1,40 @@
+package helper
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForEach(t *testing.T) {
+	for _, concurrency := range []int{0, 1, 4} {
+		seen := make([]int32, 10)
+		ForEach(concurrency, len(seen), func(i int) {
+			atomic.AddInt32(&seen[i], 1)
+		})
+		for i := range seen {
+			assert.Equal(t, int32(1), seen[i])
+		}
+	}
+
+	ForEach(4, 0, func(i int) {
+		t.Fatal("fn must not be called")
+	})
+}
+
+func TestConsume(t *testing.T) {
+	in := make(chan int)
+	go func() {
+		for i := 1; i <= 10; i++ {
+			in <- i
+		}
+		close(in)
+	}()
+
+	var sum int64
+	Consume(4, in, func(v int) {
+		atomic.AddInt64(&sum, int64(v))
+	})
+	assert.Equal(t, int64(55), sum)
+}
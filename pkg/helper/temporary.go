@@ -0,0 +1,18 @@
+package helper
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+// TemporaryPadID returns the ID for a temporary pad which replaces the pad, e.g. "pad-import-tmp-3f9a1c0b7d2e4f60".
+// The random part can not be guessed, so the ID does not belong to a pad of a user.
+func TemporaryPadID(pad, suffix string) (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s-%s-%s", pad, suffix, hex.EncodeToString(b)), nil
+}
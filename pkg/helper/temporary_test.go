@@ -0,0 +1,17 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemporaryPadID(t *testing.T) {
+	id, err := TemporaryPadID("pad", "import-tmp")
+	assert.Nil(t, err)
+	assert.Regexp(t, `^pad-import-tmp-[0-9a-f]{16}$`, id)
+
+	other, err := TemporaryPadID("pad", "import-tmp")
+	assert.Nil(t, err)
+	assert.NotEqual(t, id, other)
+}
@@ -0,0 +1,203 @@
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/export"
+)
+
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+)
+
+var (
+	revisionKey  = regexp.MustCompile(`^pad:.+:revs:(\d+)$`)
+	authorKey    = regexp.MustCompile(`^globalAuthor:(.+)$`)
+	headerFormat = regexp.MustCompile(`^Z:([0-9a-z]+)([><])([0-9a-z]+)`)
+	opFormat     = regexp.MustCompile(`((?:\*[0-9a-z]+)*)(?:\|([0-9a-z]+))?([-+=])([0-9a-z]+)`)
+)
+
+// Revision is a change of a pad.
+type Revision struct {
+	Number     int       `json:"revision"`
+	Author     string    `json:"author"`
+	AuthorName string    `json:"authorName,omitempty"`
+	Timestamp  time.Time `json:"timestamp"`
+	Added      int       `json:"added"`
+	Removed    int       `json:"removed"`
+	Length     int       `json:"length"`
+}
+
+// Changeset contains the lengths of an Etherpad changeset.
+type Changeset struct {
+	OldLength int
+	NewLength int
+	Added     int
+	Removed   int
+}
+
+// ParseChangeset reads the lengths and the number of added and removed characters of a changeset,
+// e.g. "Z:5c>5*0+5$Hello".
+func ParseChangeset(changeset string) (Changeset, error) {
+	var cs Changeset
+
+	header := headerFormat.FindStringSubmatch(changeset)
+	if header == nil {
+		return cs, fmt.Errorf("invalid changeset: %q", changeset)
+	}
+	oldLength, err := strconv.ParseInt(header[1], 36, 64)
+	if err != nil {
+		return cs, err
+	}
+	diff, err := strconv.ParseInt(header[3], 36, 64)
+	if err != nil {
+		return cs, err
+	}
+	cs.OldLength = int(oldLength)
+	cs.NewLength = cs.OldLength + int(diff)
+	if header[2] == "<" {
+		cs.NewLength = cs.OldLength - int(diff)
+	}
+
+	ops := changeset[len(header[0]):]
+	if i := strings.Index(ops, "$"); i >= 0 {
+		ops = ops[:i]
+	}
+	for _, op := range opFormat.FindAllStringSubmatch(ops, -1) {
+		chars, err := strconv.ParseInt(op[4], 36, 64)
+		if err != nil {
+			return cs, err
+		}
+		switch op[3] {
+		case "+":
+			cs.Added += int(chars)
+		case "-":
+			cs.Removed += int(chars)
+		}
+	}
+
+	return cs, nil
+}
+
+// ParseExport reads the revisions from a pad in the .etherpad format, ordered by the revision number.
+func ParseExport(data []byte) ([]Revision, error) {
+	var entries map[string]json.RawMessage
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]string)
+	for key, value := range entries {
+		match := authorKey.FindStringSubmatch(key)
+		if match == nil {
+			continue
+		}
+		var author struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(value, &author); err == nil {
+			names[match[1]] = author.Name
+		}
+	}
+
+	var revisions []Revision
+	for key, value := range entries {
+		match := revisionKey.FindStringSubmatch(key)
+		if match == nil {
+			continue
+		}
+
+		var rev struct {
+			Changeset string `json:"changeset"`
+			Meta      struct {
+				Author    string `json:"author"`
+				Timestamp int64  `json:"timestamp"`
+			} `json:"meta"`
+		}
+		if err := json.Unmarshal(value, &rev); err != nil {
+			return nil, fmt.Errorf("invalid revision %s: %w", match[1], err)
+		}
+		cs, err := ParseChangeset(rev.Changeset)
+		if err != nil {
+			return nil, fmt.Errorf("invalid revision %s: %w", match[1], err)
+		}
+
+		number, _ := strconv.Atoi(match[1])
+		revisions = append(revisions, Revision{
+			Number:     number,
+			Author:     rev.Meta.Author,
+			AuthorName: names[rev.Meta.Author],
+			Timestamp:  time.Unix(0, rev.Meta.Timestamp*int64(time.Millisecond)),
+			Added:      cs.Added,
+			Removed:    cs.Removed,
+			Length:     cs.NewLength,
+		})
+	}
+	if len(revisions) == 0 {
+		return nil, errors.New("no revisions found")
+	}
+
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].Number < revisions[j].Number })
+
+	return revisions, nil
+}
+
+// Load downloads the pad in the .etherpad format and returns its revisions.
+func Load(ep *pkg.Etherpad, pad string) ([]Revision, error) {
+	data, err := ep.ExportPad(pad, export.FormatEtherpad, pkg.LatestRevision)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseExport(data)
+}
+
+// Write writes the revisions in the format.
+func Write(w io.Writer, format string, revisions []Revision) error {
+	switch format {
+	case FormatTable:
+		return writeTable(w, revisions)
+	case FormatJSON:
+		if revisions == nil {
+			revisions = []Revision{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(revisions)
+	}
+
+	return fmt.Errorf("unknown format: %s", format)
+}
+
+func writeTable(w io.Writer, revisions []Revision) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "REVISION\tTIMESTAMP\tAUTHOR\tADDED\tREMOVED\tLENGTH"); err != nil {
+		return err
+	}
+
+	for _, rev := range revisions {
+		author := rev.Author
+		if author == "" {
+			author = "-"
+		}
+		if rev.AuthorName != "" {
+			author = fmt.Sprintf("%s (%s)", rev.AuthorName, rev.Author)
+		}
+		if _, err := fmt.Fprintf(tw, "%d\t%s\t%s\t%d\t%d\t%d\n", rev.Number, rev.Timestamp.Format(time.RFC3339), author, rev.Added, rev.Removed, rev.Length); err != nil {
+			return err
+		}
+	}
+
+	return tw.Flush()
+}
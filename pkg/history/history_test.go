@@ -0,0 +1,87 @@
+package history
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/systemli/etherpad-toolkit/pkg"
+)
+
+const padExport = `{
+  "pad:test": {"atext": {"text": "Hello World\n", "attribs": "*0+c"}, "pool": {"numToAttrib": {"0": ["author", "a.alice"]}, "nextNum": 1}, "head": 2},
+  "globalAuthor:a.alice": {"colorId": 1, "name": "Alice", "timestamp": 1609556645000},
+  "pad:test:revs:2": {"changeset": "Z:g<6|1=6-6*0|1+1$\n", "meta": {"author": "a.bob", "timestamp": 1609556705000}},
+  "pad:test:revs:0": {"changeset": "Z:1>a|1+a$Hello Wor\n", "meta": {"author": "", "timestamp": 1609556585000}},
+  "pad:test:revs:1": {"changeset": "Z:b>6=9*0+6$ld you", "meta": {"author": "a.alice", "timestamp": 1609556645000}},
+  "pad:test:chat:0": {"text": "hi", "userId": "a.alice", "time": 1609556645000}
+}`
+
+func TestParseChangeset(t *testing.T) {
+	cs, err := ParseChangeset("Z:5c>5*0+5$Hello")
+	assert.Nil(t, err)
+	assert.Equal(t, Changeset{OldLength: 192, NewLength: 197, Added: 5}, cs)
+
+	cs, err = ParseChangeset("Z:g<6|1=6-6*0|1+1$\n")
+	assert.Nil(t, err)
+	assert.Equal(t, Changeset{OldLength: 16, NewLength: 10, Added: 1, Removed: 6}, cs)
+
+	_, err = ParseChangeset("invalid")
+	assert.EqualError(t, err, `invalid changeset: "invalid"`)
+}
+
+func TestParseExport(t *testing.T) {
+	revisions, err := ParseExport([]byte(padExport))
+	assert.Nil(t, err)
+	assert.Len(t, revisions, 3)
+
+	assert.Equal(t, 0, revisions[0].Number)
+	assert.Equal(t, "", revisions[0].Author)
+	assert.Equal(t, 10, revisions[0].Added)
+	assert.Equal(t, 11, revisions[0].Length)
+
+	assert.Equal(t, 1, revisions[1].Number)
+	assert.Equal(t, "a.alice", revisions[1].Author)
+	assert.Equal(t, "Alice", revisions[1].AuthorName)
+	assert.Equal(t, int64(1609556645), revisions[1].Timestamp.Unix())
+
+	assert.Equal(t, Revision{Number: 2, Author: "a.bob", Timestamp: time.Unix(1609556705, 0), Added: 1, Removed: 6, Length: 10}, revisions[2])
+
+	_, err = ParseExport([]byte(`{"pad:test": {}}`))
+	assert.EqualError(t, err, "no revisions found")
+}
+
+func TestLoad(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/p/test/export/etherpad", r.URL.Path)
+		_, _ = w.Write([]byte(padExport))
+	}))
+	defer ts.Close()
+
+	revisions, err := Load(pkg.NewEtherpadClient(ts.URL, ""), "test")
+	assert.Nil(t, err)
+	assert.Len(t, revisions, 3)
+}
+
+func TestWrite(t *testing.T) {
+	revisions := []Revision{
+		{Number: 0, Timestamp: time.Unix(1609556585, 0).UTC(), Added: 10, Length: 11},
+		{Number: 1, Author: "a.alice", AuthorName: "Alice", Timestamp: time.Unix(1609556645, 0).UTC(), Added: 6, Length: 17},
+	}
+
+	var b bytes.Buffer
+	assert.Nil(t, Write(&b, FormatTable, revisions))
+	assert.Equal(t, `REVISION  TIMESTAMP             AUTHOR           ADDED  REMOVED  LENGTH
+0         2021-01-02T03:03:05Z  -                10     0        11
+1         2021-01-02T03:04:05Z  Alice (a.alice)  6      0        17
+`, b.String())
+
+	b.Reset()
+	assert.Nil(t, Write(&b, FormatJSON, nil))
+	assert.Equal(t, "[]\n", b.String())
+
+	assert.EqualError(t, Write(&b, "xml", revisions), "unknown format: xml")
+}
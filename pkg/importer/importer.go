@@ -0,0 +1,248 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/helper"
+)
+
+// temporarySuffix is appended with a random part to the pad ID of the temporary pad which replaces an existing pad.
+const temporarySuffix = "import-tmp"
+
+// Extensions are the supported file extensions.
+var Extensions = []string{".txt", ".html", ".md", ".etherpad"}
+
+// Source is a file which is imported into the pad.
+type Source struct {
+	Path string
+	Pad  string
+}
+
+// Collect returns the sources for a file or all supported files in a directory tree. The pad ID is the unescaped file
+// name without extension, as written by export.
+func Collect(path string) ([]Source, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		source, err := sourceOf(path)
+		if err != nil {
+			return nil, err
+		}
+		return []Source{source}, nil
+	}
+
+	var sources []Source
+	pads := make(map[string]string)
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !supported(p) {
+			return err
+		}
+
+		source, err := sourceOf(p)
+		if err != nil {
+			return err
+		}
+		if other, ok := pads[source.Pad]; ok {
+			return fmt.Errorf("pad %s is defined by %s and %s", source.Pad, other, p)
+		}
+		pads[source.Pad] = p
+		sources = append(sources, source)
+
+		return nil
+	})
+
+	return sources, err
+}
+
+// LoadMapping reads the sources from a CSV file with the columns file and pad. Relative paths are resolved against
+// the directory of the mapping file. Lines starting with # are ignored.
+func LoadMapping(path string) ([]Source, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var sources []Source
+	for _, record := range records {
+		file := record[0]
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(filepath.Dir(path), file)
+		}
+		if !supported(file) {
+			return nil, fmt.Errorf("unsupported file: %s", file)
+		}
+		sources = append(sources, Source{Path: file, Pad: record[1]})
+	}
+
+	return sources, nil
+}
+
+func sourceOf(path string) (Source, error) {
+	if !supported(path) {
+		return Source{}, fmt.Errorf("unsupported file: %s", path)
+	}
+
+	name := filepath.Base(path)
+	pad, err := url.PathUnescape(strings.TrimSuffix(name, filepath.Ext(name)))
+	if err != nil {
+		return Source{}, err
+	}
+
+	return Source{Path: path, Pad: pad}, nil
+}
+
+func supported(path string) bool {
+	ext := filepath.Ext(path)
+	for _, e := range Extensions {
+		if ext == e {
+			return true
+		}
+	}
+
+	return false
+}
+
+type Importer struct {
+	etherpad *pkg.Etherpad
+	force    bool
+	dryRun   bool
+}
+
+// NewImporter returns a instance of Importer. If force is true and the pad exists, it will be overwritten.
+func NewImporter(ep *pkg.Etherpad, force, dryRun bool) *Importer {
+	return &Importer{
+		etherpad: ep,
+		force:    force,
+		dryRun:   dryRun,
+	}
+}
+
+// ImportAll imports the sources and returns the number of failed imports.
+func (i *Importer) ImportAll(sources []Source) (int, error) {
+	pads, err := i.etherpad.ListAllPads()
+	if err != nil {
+		return 0, err
+	}
+	existing := make(map[string]bool)
+	for _, pad := range pads {
+		existing[pad] = true
+	}
+
+	failed := 0
+	for _, source := range sources {
+		if err := i.Import(source, existing[source.Pad]); err != nil {
+			log.WithError(err).WithFields(log.Fields{"file": source.Path, "pad": source.Pad}).Error("failed to import pad")
+			failed++
+		}
+	}
+
+	return failed, nil
+}
+
+// Import creates the pad from the source. Existing pads are only changed if force is set.
+func (i *Importer) Import(source Source, exists bool) error {
+	if exists && !i.force {
+		return errors.New("pad already exists")
+	}
+
+	data, err := os.ReadFile(source.Path)
+	if err != nil {
+		return err
+	}
+
+	return i.ImportData(source.Pad, filepath.Base(source.Path), data, exists)
+}
+
+// ImportData creates the pad from the content of a file with the name. Text and Markdown are imported as text, HTML
+// with setHTML and .etherpad files with history and chat via the import endpoint. Existing pads are only changed if
+// force is set: the content of text and HTML is replaced, .etherpad files are imported into a temporary pad which
+// replaces the pad afterwards. The pad is kept if the import fails.
+func (i *Importer) ImportData(pad, name string, data []byte, exists bool) error {
+	if exists && !i.force {
+		return errors.New("pad already exists")
+	}
+
+	log.WithFields(log.Fields{"file": name, "pad": pad, "overwrite": exists}).Info("Import Pad")
+	if i.dryRun {
+		return nil
+	}
+
+	var err error
+	switch filepath.Ext(name) {
+	case ".etherpad":
+		if exists {
+			return i.replace(pad, name, data)
+		}
+		return i.etherpad.ImportPad(pad, name, data)
+	case ".html":
+		if !exists {
+			if err = i.etherpad.CreatePad(pad, ""); err != nil {
+				return err
+			}
+		}
+		return i.etherpad.SetHTML(pad, string(data))
+	default:
+		// Etherpad creates the pad with the default text if the text is empty.
+		if !exists && len(data) > 0 {
+			return i.etherpad.CreatePad(pad, string(data))
+		}
+		if !exists {
+			if err = i.etherpad.CreatePad(pad, ""); err != nil {
+				return err
+			}
+		}
+		return i.etherpad.SetText(pad, string(data))
+	}
+}
+
+// replace imports the .etherpad file into a temporary pad and moves it to the pad. Etherpad imports .etherpad files
+// only into pads without history, so the pad can not be imported in place.
+func (i *Importer) replace(pad, name string, data []byte) error {
+	tmp, err := helper.TemporaryPadID(pad, temporarySuffix)
+	if err != nil {
+		return err
+	}
+
+	// the ID is random, but an existing pad must never be replaced, errors of Etherpad mean that it does not exist
+	var apiErr *pkg.APIError
+	if _, err = i.etherpad.GetRevisionsCount(tmp); err == nil {
+		return fmt.Errorf("temporary pad %s exists", tmp)
+	} else if !errors.As(err, &apiErr) {
+		return err
+	}
+
+	err = i.etherpad.ImportPad(tmp, name, data)
+	if err == nil {
+		err = i.etherpad.MovePad(tmp, pad, true)
+	}
+	if err != nil {
+		if deleteErr := i.etherpad.DeletePad(tmp); deleteErr != nil && !errors.As(deleteErr, &apiErr) {
+			log.WithError(deleteErr).WithField("pad", tmp).Error("failed to remove temporary pad")
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,137 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/etherpadtest"
+)
+
+func newFake() *etherpadtest.Fake {
+	return &etherpadtest.Fake{
+		Responses: map[string]etherpadtest.Response{
+			"listAllPads": etherpadtest.Data(`{"padIDs": ["pad1", "pad3"]}`),
+		},
+		Missing: func(pad string) bool {
+			return strings.Contains(pad, "-tmp-")
+		},
+	}
+}
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func TestCollect(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"pad1.txt":            "Hello",
+		"sub/g.abc$pad2.html": "<b>Hello</b>",
+		"sub/pad%2F3.md":      "**Hello**",
+		"manifest.json":       "{}",
+	})
+
+	sources, err := Collect(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, []Source{
+		{Path: filepath.Join(dir, "pad1.txt"), Pad: "pad1"},
+		{Path: filepath.Join(dir, "sub/g.abc$pad2.html"), Pad: "g.abc$pad2"},
+		{Path: filepath.Join(dir, "sub/pad%2F3.md"), Pad: "pad/3"},
+	}, sources)
+
+	sources, err = Collect(filepath.Join(dir, "pad1.txt"))
+	assert.Nil(t, err)
+	assert.Len(t, sources, 1)
+
+	_, err = Collect(filepath.Join(dir, "manifest.json"))
+	assert.Error(t, err)
+
+	dir = writeFiles(t, map[string]string{"pad1.txt": "Hello", "sub/pad1.md": "Hello"})
+	_, err = Collect(dir)
+	assert.Error(t, err)
+}
+
+func TestLoadMapping(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"mapping.csv": "# file,pad\nnotes.txt,pad1\n/tmp/other.html, pad2\n",
+	})
+
+	sources, err := LoadMapping(filepath.Join(dir, "mapping.csv"))
+	assert.Nil(t, err)
+	assert.Equal(t, []Source{
+		{Path: filepath.Join(dir, "notes.txt"), Pad: "pad1"},
+		{Path: "/tmp/other.html", Pad: "pad2"},
+	}, sources)
+
+	dir = writeFiles(t, map[string]string{"mapping.csv": "notes.pdf,pad1\n"})
+	_, err = LoadMapping(filepath.Join(dir, "mapping.csv"))
+	assert.Error(t, err)
+}
+
+func TestImporter_ImportAll(t *testing.T) {
+	fake := newFake()
+	ts := etherpadtest.NewServer(fake)
+	defer ts.Close()
+
+	dir := writeFiles(t, map[string]string{
+		"pad1.txt":      "Hello",
+		"pad2.html":     "<b>Hello</b>",
+		"pad3.etherpad": "{}",
+		"pad4.md":       "",
+	})
+	sources, err := Collect(dir)
+	assert.Nil(t, err)
+
+	failed, err := NewImporter(pkg.NewEtherpadClient(ts.URL, ""), false, false).ImportAll(sources)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, failed)
+	assert.Equal(t, []string{"listAllPads", "createPad pad2", "setHTML pad2", "createPad pad4", "setText pad4"}, fake.Calls())
+
+	fake.Reset()
+	failed, err = NewImporter(pkg.NewEtherpadClient(ts.URL, ""), true, false).ImportAll(sources)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, failed)
+	assert.Equal(t, []string{"listAllPads", "setText pad1", "createPad pad2", "setHTML pad2", "getRevisionsCount pad3-import-tmp-*", "import pad3-import-tmp-*", "movePad pad3-import-tmp-* pad3", "createPad pad4", "setText pad4"}, fake.Calls())
+
+	fake.Reset()
+	failed, err = NewImporter(pkg.NewEtherpadClient(ts.URL, ""), true, true).ImportAll(sources)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, failed)
+	assert.Equal(t, []string{"listAllPads"}, fake.Calls())
+}
+
+func TestImporter_ImportData_Failed(t *testing.T) {
+	fake := newFake()
+	fake.Errors = map[string]bool{"import": true}
+	ts := etherpadtest.NewServer(fake)
+	defer ts.Close()
+
+	err := NewImporter(pkg.NewEtherpadClient(ts.URL, ""), true, false).ImportData("pad3", "pad3.etherpad", []byte("{}"), true)
+	assert.NotNil(t, err)
+	assert.Equal(t, []string{"getRevisionsCount pad3-import-tmp-*", "import pad3-import-tmp-*", "deletePad pad3-import-tmp-*"}, fake.Calls())
+}
+
+func TestImporter_ImportData_TemporaryPadExists(t *testing.T) {
+	fake := newFake()
+	fake.Missing = nil
+	ts := etherpadtest.NewServer(fake)
+	defer ts.Close()
+
+	err := NewImporter(pkg.NewEtherpadClient(ts.URL, ""), true, false).ImportData("pad3", "pad3.etherpad", []byte("{}"), true)
+	assert.Regexp(t, `^temporary pad pad3-import-tmp-[0-9a-f]{16} exists$`, err.Error())
+	assert.Equal(t, []string{"getRevisionsCount pad3-import-tmp-*"}, fake.Calls())
+}
@@ -0,0 +1,158 @@
+package info
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/helper"
+)
+
+// Author is a author who contributed to the pad.
+type Author struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Retention describes when the pad will be removed by purge.
+type Retention struct {
+	Rule      string    `json:"rule"`
+	Duration  string    `json:"duration"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	Expired   bool      `json:"expired"`
+}
+
+// PadInfo contains the metadata of a pad. PublicStatus and PasswordProtected are only set for group pads.
+type PadInfo struct {
+	ID                string     `json:"id"`
+	Revisions         int        `json:"revisions"`
+	LastEdited        time.Time  `json:"lastEdited"`
+	SavedRevisions    []int      `json:"savedRevisions"`
+	Authors           []Author   `json:"authors"`
+	Users             int        `json:"users"`
+	ReadOnlyID        string     `json:"readOnlyId"`
+	PublicStatus      *bool      `json:"publicStatus,omitempty"`
+	PasswordProtected *bool      `json:"passwordProtected,omitempty"`
+	ChatMessages      int        `json:"chatMessages"`
+	TextLength        int        `json:"textLength"`
+	TextLines         int        `json:"textLines"`
+	Empty             bool       `json:"empty"`
+	Retention         *Retention `json:"retention,omitempty"`
+}
+
+type Inspector struct {
+	etherpad *pkg.Etherpad
+
+	// Expiration enables the retention rule and expiry date as applied by purge.
+	Expiration helper.PadExpiration
+	// DefaultPadText is treated as empty content in addition to whitespace.
+	DefaultPadText string
+}
+
+// NewInspector returns a instance of Inspector.
+func NewInspector(ep *pkg.Etherpad) *Inspector {
+	return &Inspector{etherpad: ep}
+}
+
+// Inspect collects the metadata of the pad.
+func (i *Inspector) Inspect(pad string) (*PadInfo, error) {
+	var err error
+	info := &PadInfo{ID: pad}
+
+	if info.Revisions, err = i.etherpad.GetRevisionsCount(pad); err != nil {
+		return nil, err
+	}
+	if info.LastEdited, err = i.etherpad.GetLastEdited(pad); err != nil {
+		return nil, err
+	}
+	if info.SavedRevisions, err = i.etherpad.ListSavedRevisions(pad); err != nil {
+		return nil, err
+	}
+	if info.Users, err = i.etherpad.PadUsersCount(pad); err != nil {
+		return nil, err
+	}
+	if info.ReadOnlyID, err = i.etherpad.GetReadOnlyID(pad); err != nil {
+		return nil, err
+	}
+
+	head, err := i.etherpad.GetChatHead(pad)
+	if err != nil {
+		return nil, err
+	}
+	info.ChatMessages = head + 1
+
+	text, err := i.etherpad.GetText(pad)
+	if err != nil {
+		return nil, err
+	}
+	info.TextLength = utf8.RuneCountInString(text)
+	info.TextLines = strings.Count(text, "\n")
+	info.Empty = helper.IsEmptyText(text, i.DefaultPadText)
+
+	if info.Authors, err = i.authors(pad); err != nil {
+		return nil, err
+	}
+
+	// The public status and the password can only be set for group pads.
+	if strings.Contains(pad, "$") {
+		public, err := i.etherpad.GetPublicStatus(pad)
+		if err != nil {
+			return nil, err
+		}
+		info.PublicStatus = &public
+
+		protected, err := i.etherpad.IsPasswordProtected(pad)
+		if err != nil {
+			return nil, err
+		}
+		info.PasswordProtected = &protected
+	}
+
+	if i.Expiration != nil {
+		info.Retention = i.retention(info, time.Now())
+	}
+
+	return info, nil
+}
+
+// authors returns the authors of the pad. Missing names are logged and left empty.
+func (i *Inspector) authors(pad string) ([]Author, error) {
+	ids, err := i.etherpad.ListAuthorsOfPad(pad)
+	if err != nil {
+		return nil, err
+	}
+
+	authors := make([]Author, 0, len(ids))
+	for _, id := range ids {
+		name, err := i.etherpad.GetAuthorName(id)
+		if err != nil {
+			log.WithError(err).WithField("author", id).Warn("failed to get author name")
+		}
+		authors = append(authors, Author{ID: id, Name: name})
+	}
+
+	return authors, nil
+}
+
+// retention returns the rule which purge applies to the pad. The expiration for empty pads applies if it is earlier
+// than the expiration by suffix.
+func (i *Inspector) retention(info *PadInfo, now time.Time) *Retention {
+	rule := i.Expiration.GetRule(info.ID)
+	duration := i.Expiration[rule]
+
+	if emptyDuration, ok := i.Expiration.GetEmptyDuration(); ok && info.Empty && -emptyDuration < duration {
+		rule = helper.EmptyKey
+		duration = -emptyDuration
+	}
+
+	expiresAt := info.LastEdited.Add(duration)
+
+	return &Retention{
+		Rule:      rule,
+		Duration:  duration.String(),
+		ExpiresAt: expiresAt,
+		Expired:   expiresAt.Before(now) || info.Revisions == 0,
+	}
+}
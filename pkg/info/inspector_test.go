@@ -0,0 +1,97 @@
+package info
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/helper"
+)
+
+func etherpadServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+		w.WriteHeader(http.StatusOK)
+		switch method {
+		case "getRevisionsCount":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"revisions": 42}}`))
+		case "getLastEdited":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"lastEdited": 1609556645000}}`))
+		case "listSavedRevisions":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"savedRevisions": [2, 40]}}`))
+		case "padUsersCount":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"padUsersCount": 1}}`))
+		case "getReadOnlyID":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"readOnlyID": "r.abc"}}`))
+		case "getChatHead":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"chatHead": 2}}`))
+		case "getText":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"text": " \n\n"}}`))
+		case "listAuthorsOfPad":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"authorIDs": ["a.1", "a.2"]}}`))
+		case "getAuthorName":
+			if r.URL.Query().Get("authorID") == "a.2" {
+				_, _ = w.Write([]byte(`{"code": 1, "message":"authorID does not exist", "data": null}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": "John"}`))
+		case "getPublicStatus":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"publicStatus": true}}`))
+		case "isPasswordProtected":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"isPasswordProtected": false}}`))
+		default:
+			_, _ = w.Write([]byte(`{"code": 1, "message":"padID does not exist", "data": null}`))
+		}
+	}))
+}
+
+func TestInspector_Inspect(t *testing.T) {
+	ts := etherpadServer()
+	defer ts.Close()
+
+	inspector := NewInspector(pkg.NewEtherpadClient(ts.URL, ""))
+
+	info, err := inspector.Inspect("pad-keep")
+	assert.Nil(t, err)
+	assert.Equal(t, 42, info.Revisions)
+	assert.Equal(t, []int{2, 40}, info.SavedRevisions)
+	assert.Equal(t, []Author{{ID: "a.1", Name: "John"}, {ID: "a.2"}}, info.Authors)
+	assert.Equal(t, "r.abc", info.ReadOnlyID)
+	assert.Equal(t, 3, info.ChatMessages)
+	assert.Equal(t, 3, info.TextLength)
+	assert.True(t, info.Empty)
+	assert.Nil(t, info.PublicStatus)
+	assert.Nil(t, info.Retention)
+
+	info, err = inspector.Inspect("g.abc$pad")
+	assert.Nil(t, err)
+	assert.True(t, *info.PublicStatus)
+	assert.False(t, *info.PasswordProtected)
+}
+
+func TestInspector_Retention(t *testing.T) {
+	exp, err := helper.ParsePadExpiration("default:720h,keep:8760h,:empty:24h")
+	assert.Nil(t, err)
+
+	inspector := NewInspector(nil)
+	inspector.Expiration = exp
+
+	lastEdited := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := lastEdited.Add(48 * time.Hour)
+
+	retention := inspector.retention(&PadInfo{ID: "pad-keep", LastEdited: lastEdited, Revisions: 1}, now)
+	assert.Equal(t, &Retention{Rule: "keep", Duration: "8760h0m0s", ExpiresAt: lastEdited.Add(8760 * time.Hour)}, retention)
+
+	retention = inspector.retention(&PadInfo{ID: "pad-keep", LastEdited: lastEdited, Revisions: 1, Empty: true}, now)
+	assert.Equal(t, helper.EmptyKey, retention.Rule)
+	assert.True(t, retention.Expired)
+
+	retention = inspector.retention(&PadInfo{ID: "pad", LastEdited: lastEdited}, now)
+	assert.Equal(t, helper.DefaultSuffix, retention.Rule)
+	assert.True(t, retention.Expired)
+}
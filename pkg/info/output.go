@@ -0,0 +1,101 @@
+package info
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Write writes the pad info in the format.
+func Write(w io.Writer, format string, info *PadInfo) error {
+	switch format {
+	case FormatText:
+		return writeText(w, info)
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(info)
+	}
+
+	return fmt.Errorf("unknown format: %s", format)
+}
+
+func writeText(w io.Writer, info *PadInfo) error {
+	savedRevisions := make([]string, 0, len(info.SavedRevisions))
+	for _, rev := range info.SavedRevisions {
+		savedRevisions = append(savedRevisions, strconv.Itoa(rev))
+	}
+
+	authors := make([]string, 0, len(info.Authors))
+	for _, author := range info.Authors {
+		if author.Name == "" {
+			authors = append(authors, author.ID)
+			continue
+		}
+		authors = append(authors, fmt.Sprintf("%s (%s)", author.ID, author.Name))
+	}
+
+	rows := [][2]string{
+		{"Pad", info.ID},
+		{"Revisions", strconv.Itoa(info.Revisions)},
+		{"Last Edited", info.LastEdited.Format(time.RFC3339)},
+		{"Saved Revisions", orDash(strings.Join(savedRevisions, ", "))},
+		{"Authors", orDash(strings.Join(authors, ", "))},
+		{"Connected Users", strconv.Itoa(info.Users)},
+		{"Read-only ID", info.ReadOnlyID},
+		{"Public", yesNo(info.PublicStatus)},
+		{"Password Protected", yesNo(info.PasswordProtected)},
+		{"Chat Messages", strconv.Itoa(info.ChatMessages)},
+		{"Text Size", fmt.Sprintf("%d characters, %d lines", info.TextLength, info.TextLines)},
+		{"Empty", yesNo(&info.Empty)},
+	}
+
+	if info.Retention != nil {
+		expires := info.Retention.ExpiresAt.Format(time.RFC3339)
+		if info.Retention.Expired {
+			expires += " (expired)"
+		}
+		rows = append(rows,
+			[2]string{"Retention Rule", fmt.Sprintf("%s (%s)", info.Retention.Rule, info.Retention.Duration)},
+			[2]string{"Expires", expires},
+		)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, row := range rows {
+		if _, err := fmt.Fprintf(tw, "%s:\t%s\n", row[0], row[1]); err != nil {
+			return err
+		}
+	}
+
+	return tw.Flush()
+}
+
+// yesNo returns "-" for unset values, e.g. the public status of pads without group.
+func yesNo(b *bool) string {
+	if b == nil {
+		return "-"
+	}
+	if *b {
+		return "yes"
+	}
+
+	return "no"
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+
+	return s
+}
@@ -0,0 +1,40 @@
+package info
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrite(t *testing.T) {
+	public := true
+	info := &PadInfo{
+		ID:           "g.abc$pad",
+		Revisions:    42,
+		LastEdited:   time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC),
+		Authors:      []Author{{ID: "a.1", Name: "John"}, {ID: "a.2"}},
+		PublicStatus: &public,
+		TextLength:   10,
+		TextLines:    2,
+		Retention:    &Retention{Rule: "default", Duration: "720h0m0s", ExpiresAt: time.Date(2021, 2, 1, 3, 4, 5, 0, time.UTC), Expired: true},
+	}
+
+	var b bytes.Buffer
+	err := Write(&b, FormatText, info)
+	assert.Nil(t, err)
+	assert.Contains(t, b.String(), "Authors:             a.1 (John), a.2\n")
+	assert.Contains(t, b.String(), "Public:              yes\n")
+	assert.Contains(t, b.String(), "Password Protected:  -\n")
+	assert.Contains(t, b.String(), "Expires:             2021-02-01T03:04:05Z (expired)\n")
+
+	b.Reset()
+	err = Write(&b, FormatJSON, info)
+	assert.Nil(t, err)
+	assert.Contains(t, b.String(), `"publicStatus": true`)
+	assert.NotContains(t, b.String(), "passwordProtected")
+
+	err = Write(&b, "xml", info)
+	assert.Error(t, err)
+}
@@ -0,0 +1,210 @@
+package list
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/helper"
+)
+
+const (
+	FieldID         = "id"
+	FieldLastEdited = "last-edited"
+	FieldRevisions  = "revisions"
+	FieldUsers      = "users"
+	FieldAuthors    = "authors"
+)
+
+// Fields are the fields which can be fetched in addition to the pad ID.
+var Fields = []string{FieldLastEdited, FieldRevisions, FieldUsers, FieldAuthors}
+
+// Pad is a pad with the optionally fetched fields. Fields which were not fetched or failed to fetch are nil.
+type Pad struct {
+	ID         string     `json:"id"`
+	LastEdited *time.Time `json:"lastEdited,omitempty"`
+	Revisions  *int       `json:"revisions,omitempty"`
+	Users      *int       `json:"users,omitempty"`
+	Authors    *int       `json:"authors,omitempty"`
+}
+
+type Lister struct {
+	etherpad *pkg.Etherpad
+
+	// Filter selects the pads to list. All pads are listed if nil.
+	Filter *helper.PadFilter
+	// Fields are fetched for every listed pad.
+	Fields []string
+	// SortBy is the field to sort the pads by. The field is fetched if it is not in Fields.
+	SortBy string
+	// Reverse sorts the pads in descending order.
+	Reverse bool
+	// Limit is the maximum number of pads to list. All pads are listed if 0.
+	Limit int
+	// Concurrency is the number of parallel API calls to fetch the fields.
+	Concurrency int
+}
+
+// NewLister returns a instance of Lister which sorts the pads by ID.
+func NewLister(ep *pkg.Etherpad) *Lister {
+	return &Lister{
+		etherpad:    ep,
+		SortBy:      FieldID,
+		Concurrency: 4,
+	}
+}
+
+// List returns the filtered, sorted and limited pads with the configured fields.
+func (l *Lister) List() ([]Pad, error) {
+	fields := append([]string{}, l.Fields...)
+	if l.SortBy != FieldID && !contains(fields, l.SortBy) {
+		fields = append(fields, l.SortBy)
+	}
+	for _, field := range fields {
+		if !contains(Fields, field) {
+			return nil, fmt.Errorf("unknown field: %s", field)
+		}
+	}
+
+	ids, err := l.etherpad.ListAllPads()
+	if err != nil {
+		return nil, err
+	}
+	if l.Filter != nil {
+		ids = l.Filter.Apply(ids)
+	}
+
+	pads := make([]Pad, len(ids))
+	for i, id := range ids {
+		pads[i] = Pad{ID: id}
+	}
+
+	// The fields are only needed for the selected pads if the pads are sorted by ID.
+	if l.SortBy == FieldID {
+		pads = l.limit(Sort(pads, FieldID, l.Reverse))
+		l.fetch(pads, fields)
+		return pads, nil
+	}
+
+	l.fetch(pads, fields)
+
+	return l.limit(Sort(pads, l.SortBy, l.Reverse)), nil
+}
+
+func (l *Lister) limit(pads []Pad) []Pad {
+	if l.Limit > 0 && len(pads) > l.Limit {
+		return pads[:l.Limit]
+	}
+
+	return pads
+}
+
+// fetch requests the fields for all pads concurrently and updates the pads in place.
+func (l *Lister) fetch(pads []Pad, fields []string) {
+	if len(fields) == 0 {
+		return
+	}
+
+	helper.ForEach(l.Concurrency, len(pads), func(i int) {
+		l.fetchPad(&pads[i], fields)
+	})
+}
+
+func (l *Lister) fetchPad(pad *Pad, fields []string) {
+	for _, field := range fields {
+		var err error
+		switch field {
+		case FieldLastEdited:
+			var lastEdited time.Time
+			if lastEdited, err = l.etherpad.GetLastEdited(pad.ID); err == nil {
+				pad.LastEdited = &lastEdited
+			}
+		case FieldRevisions:
+			var revisions int
+			if revisions, err = l.etherpad.GetRevisionsCount(pad.ID); err == nil {
+				pad.Revisions = &revisions
+			}
+		case FieldUsers:
+			var users int
+			if users, err = l.etherpad.PadUsersCount(pad.ID); err == nil {
+				pad.Users = &users
+			}
+		case FieldAuthors:
+			var authors []string
+			if authors, err = l.etherpad.ListAuthorsOfPad(pad.ID); err == nil {
+				count := len(authors)
+				pad.Authors = &count
+			}
+		}
+		if err != nil {
+			log.WithError(err).WithFields(log.Fields{"pad": pad.ID, "field": field}).Warn("failed to fetch field")
+		}
+	}
+}
+
+// Sort sorts the pads by the field. Pads without a value for the field are sorted last.
+func Sort(pads []Pad, field string, reverse bool) []Pad {
+	sort.SliceStable(pads, func(i, j int) bool {
+		a, b := sortKey(pads[i], field), sortKey(pads[j], field)
+		if a == nil || b == nil {
+			return a != nil
+		}
+		if reverse {
+			return less(b, a, pads[j].ID, pads[i].ID)
+		}
+		return less(a, b, pads[i].ID, pads[j].ID)
+	})
+
+	return pads
+}
+
+func sortKey(pad Pad, field string) interface{} {
+	switch field {
+	case FieldLastEdited:
+		if pad.LastEdited != nil {
+			return pad.LastEdited.UnixNano()
+		}
+	case FieldRevisions:
+		if pad.Revisions != nil {
+			return int64(*pad.Revisions)
+		}
+	case FieldUsers:
+		if pad.Users != nil {
+			return int64(*pad.Users)
+		}
+	case FieldAuthors:
+		if pad.Authors != nil {
+			return int64(*pad.Authors)
+		}
+	default:
+		return pad.ID
+	}
+
+	return nil
+}
+
+// less compares two sort keys of the same type, equal keys are ordered by the pad ID.
+func less(a, b interface{}, idA, idB string) bool {
+	switch a := a.(type) {
+	case int64:
+		if a != b.(int64) {
+			return a < b.(int64)
+		}
+	case string:
+		return a < b.(string)
+	}
+
+	return idA < idB
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,108 @@
+package list
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/helper"
+)
+
+func etherpadServer() *httptest.Server {
+	revisions := map[string]string{"pad1": "5", "pad2-keep": "50", "pad3-keep": "20"}
+	lastEdited := map[string]string{"pad1": "1600000000000", "pad2-keep": "1500000000000", "pad3-keep": "1700000000000"}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		pad := r.URL.Query().Get("padID")
+
+		w.WriteHeader(http.StatusOK)
+		switch method {
+		case "listAllPads":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"padIDs": ["pad2-keep", "pad1", "pad3-keep"]}}`))
+		case "getRevisionsCount":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"revisions": ` + revisions[pad] + `}}`))
+		case "getLastEdited":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"lastEdited": ` + lastEdited[pad] + `}}`))
+		case "padUsersCount":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"padUsersCount": 2}}`))
+		case "listAuthorsOfPad":
+			if pad == "pad1" {
+				_, _ = w.Write([]byte(`{"code": 1, "message":"padID does not exist", "data": null}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"authorIDs": ["a.1", "a.2", "a.3"]}}`))
+		default:
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": null}`))
+		}
+	}))
+}
+
+func ids(pads []Pad) []string {
+	var result []string
+	for _, pad := range pads {
+		result = append(result, pad.ID)
+	}
+
+	return result
+}
+
+func TestLister_List(t *testing.T) {
+	ts := etherpadServer()
+	defer ts.Close()
+
+	lister := NewLister(pkg.NewEtherpadClient(ts.URL, ""))
+
+	pads, err := lister.List()
+	assert.Nil(t, err)
+	assert.Equal(t, []Pad{{ID: "pad1"}, {ID: "pad2-keep"}, {ID: "pad3-keep"}}, pads)
+
+	lister.Filter = &helper.PadFilter{Suffixes: []string{"keep"}}
+	lister.Fields = []string{FieldUsers, FieldAuthors}
+	lister.Limit = 1
+
+	pads, err = lister.List()
+	assert.Nil(t, err)
+	assert.Len(t, pads, 1)
+	assert.Equal(t, "pad2-keep", pads[0].ID)
+	assert.Equal(t, 2, *pads[0].Users)
+	assert.Equal(t, 3, *pads[0].Authors)
+	assert.Nil(t, pads[0].Revisions)
+}
+
+func TestLister_List_Sort(t *testing.T) {
+	ts := etherpadServer()
+	defer ts.Close()
+
+	lister := NewLister(pkg.NewEtherpadClient(ts.URL, ""))
+	lister.SortBy = FieldRevisions
+	lister.Reverse = true
+
+	pads, err := lister.List()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"pad2-keep", "pad3-keep", "pad1"}, ids(pads))
+	assert.Equal(t, 50, *pads[0].Revisions)
+
+	lister.SortBy = FieldLastEdited
+	lister.Reverse = false
+
+	pads, err = lister.List()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"pad2-keep", "pad1", "pad3-keep"}, ids(pads))
+
+	// pad1 fails to fetch the authors and is sorted last
+	lister.SortBy = FieldAuthors
+
+	pads, err = lister.List()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"pad2-keep", "pad3-keep", "pad1"}, ids(pads))
+	assert.Nil(t, pads[2].Authors)
+
+	lister.SortBy = "unknown"
+
+	_, err = lister.List()
+	assert.Error(t, err)
+}
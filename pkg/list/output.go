@@ -0,0 +1,118 @@
+package list
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	FormatTable  = "table"
+	FormatJSON   = "json"
+	FormatNDJSON = "ndjson"
+	FormatCSV    = "csv"
+)
+
+// Write writes the pads in the format. The columns of table and CSV are the pad ID and the given fields.
+func Write(w io.Writer, format string, pads []Pad, fields []string) error {
+	switch format {
+	case FormatTable:
+		return writeTable(w, pads, fields)
+	case FormatJSON:
+		if pads == nil {
+			pads = []Pad{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(pads)
+	case FormatNDJSON:
+		encoder := json.NewEncoder(w)
+		for _, pad := range pads {
+			if err := encoder.Encode(pad); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormatCSV:
+		return writeCSV(w, pads, fields)
+	}
+
+	return fmt.Errorf("unknown format: %s", format)
+}
+
+func writeTable(w io.Writer, pads []Pad, fields []string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	header := []string{"ID"}
+	for _, field := range fields {
+		header = append(header, strings.ToUpper(strings.ReplaceAll(field, "-", " ")))
+	}
+	if _, err := fmt.Fprintln(tw, strings.Join(header, "\t")); err != nil {
+		return err
+	}
+
+	for _, pad := range pads {
+		row := []string{pad.ID}
+		for _, field := range fields {
+			value := pad.value(field)
+			if value == "" {
+				value = "-"
+			}
+			row = append(row, value)
+		}
+		if _, err := fmt.Fprintln(tw, strings.Join(row, "\t")); err != nil {
+			return err
+		}
+	}
+
+	return tw.Flush()
+}
+
+func writeCSV(w io.Writer, pads []Pad, fields []string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(append([]string{FieldID}, fields...)); err != nil {
+		return err
+	}
+
+	for _, pad := range pads {
+		row := []string{pad.ID}
+		for _, field := range fields {
+			row = append(row, pad.value(field))
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// value returns the field formatted as string or an empty string if the field is not set.
+func (p Pad) value(field string) string {
+	var number *int
+	switch field {
+	case FieldLastEdited:
+		if p.LastEdited != nil {
+			return p.LastEdited.Format(time.RFC3339)
+		}
+		return ""
+	case FieldRevisions:
+		number = p.Revisions
+	case FieldUsers:
+		number = p.Users
+	case FieldAuthors:
+		number = p.Authors
+	}
+
+	if number == nil {
+		return ""
+	}
+
+	return strconv.Itoa(*number)
+}
@@ -0,0 +1,39 @@
+package list
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrite(t *testing.T) {
+	lastEdited := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	revisions := 10
+	pads := []Pad{{ID: "pad1", LastEdited: &lastEdited, Revisions: &revisions}, {ID: "pad2"}}
+	fields := []string{FieldLastEdited, FieldRevisions}
+
+	var b bytes.Buffer
+	err := Write(&b, FormatTable, pads, fields)
+	assert.Nil(t, err)
+	assert.Equal(t, "ID    LAST EDITED           REVISIONS\npad1  2021-01-02T03:04:05Z  10\npad2  -                     -\n", b.String())
+
+	b.Reset()
+	err = Write(&b, FormatCSV, pads, fields)
+	assert.Nil(t, err)
+	assert.Equal(t, "id,last-edited,revisions\npad1,2021-01-02T03:04:05Z,10\npad2,,\n", b.String())
+
+	b.Reset()
+	err = Write(&b, FormatNDJSON, pads, fields)
+	assert.Nil(t, err)
+	assert.Equal(t, "{\"id\":\"pad1\",\"lastEdited\":\"2021-01-02T03:04:05Z\",\"revisions\":10}\n{\"id\":\"pad2\"}\n", b.String())
+
+	b.Reset()
+	err = Write(&b, FormatJSON, nil, fields)
+	assert.Nil(t, err)
+	assert.Equal(t, "[]\n", b.String())
+
+	err = Write(&b, "xml", pads, fields)
+	assert.Error(t, err)
+}
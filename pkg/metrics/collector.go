@@ -1,40 +1,260 @@
 package metrics
 
 import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
 	"github.com/systemli/etherpad-toolkit/pkg"
 	"github.com/systemli/etherpad-toolkit/pkg/helper"
 )
 
+var (
+	ageBuckets      = []float64{3600, 86400, 604800, 2592000, 7776000, 15552000, 31536000, 63072000}
+	revisionBuckets = []float64{0, 1, 10, 100, 1000, 10000, 100000}
+)
+
 type PadCollector struct {
-	etherpad     *pkg.Etherpad
-	suffixes     []string
-	PadGaugeDesc *prometheus.Desc
+	etherpad *pkg.Etherpad
+	suffixes []string
+
+	// Expiration enables the metrics for age, revisions and expired pads per suffix group. These metrics need two
+	// API calls per pad.
+	Expiration helper.PadExpiration
+	// Users enables the metrics for connected users per suffix. These metrics need one API call per pad.
+	Users bool
+	// TopPads is the number of pads with the most connected users which are exposed with the pad name as label.
+	TopPads int
+	// Concurrency limits the parallel API calls for the pad details.
+	Concurrency int
+
+	PadGaugeDesc            *prometheus.Desc
+	PadAgeDesc              *prometheus.Desc
+	PadRevisionsDesc        *prometheus.Desc
+	PadExpiredDesc          *prometheus.Desc
+	PadUsersDesc            *prometheus.Desc
+	TopPadUsersDesc         *prometheus.Desc
+	TotalPadsDesc           *prometheus.Desc
+	TotalSessionsDesc       *prometheus.Desc
+	TotalActivePadsDesc     *prometheus.Desc
+	CacheAgeDesc            *prometheus.Desc
+	LastCollectionErrorDesc *prometheus.Desc
+	UpDesc                  *prometheus.Desc
+	ScrapeDurationDesc      *prometheus.Desc
+	LastSuccessDesc         *prometheus.Desc
+
+	apiErrors *prometheus.CounterVec
+
+	mu         sync.RWMutex
+	background bool
+	cache      *snapshot
+	updated    time.Time
+	lastErr    error
+	duration   time.Duration
+}
+
+// snapshot contains the collected data of all pads.
+type snapshot struct {
+	pads    map[string][]string
+	details map[string]*padDetails
+	users   map[string]int
+	top     []padUsers
+	stats   *pkg.Stats
+}
+
+// padUsers is the number of connected users of a pad.
+type padUsers struct {
+	pad   string
+	users int
+}
+
+// padDetails contains the age and revisions of all pads in a suffix group.
+type padDetails struct {
+	ages      []float64
+	revisions []float64
+	expired   int
 }
 
+// NewPadCollector returns a instance of PadCollector. The errors of the Etherpad client are counted by the collector.
 func NewPadCollector(etherpad *pkg.Etherpad, suffixes []string) *PadCollector {
-	return &PadCollector{
-		etherpad:     etherpad,
-		suffixes:     suffixes,
-		PadGaugeDesc: prometheus.NewDesc("etherpad_toolkit_pads", "The current number of pads", []string{"suffix"}, nil),
+	pc := &PadCollector{
+		etherpad:                etherpad,
+		suffixes:                suffixes,
+		Concurrency:             4,
+		PadGaugeDesc:            prometheus.NewDesc("etherpad_toolkit_pads", "The current number of pads", []string{"suffix"}, nil),
+		PadAgeDesc:              prometheus.NewDesc("etherpad_toolkit_pad_age_seconds", "The time since the last edit of pads", []string{"suffix"}, nil),
+		PadRevisionsDesc:        prometheus.NewDesc("etherpad_toolkit_pad_revisions", "The number of revisions of pads", []string{"suffix"}, nil),
+		PadExpiredDesc:          prometheus.NewDesc("etherpad_toolkit_pads_expired", "The current number of pads which are past their expiration", []string{"suffix"}, nil),
+		PadUsersDesc:            prometheus.NewDesc("etherpad_toolkit_pad_users", "The current number of users connected to pads", []string{"suffix"}, nil),
+		TopPadUsersDesc:         prometheus.NewDesc("etherpad_toolkit_top_pad_users", "The current number of users connected to the pads with the most users", []string{"pad"}, nil),
+		TotalPadsDesc:           prometheus.NewDesc("etherpad_toolkit_total_pads", "The total number of pads reported by Etherpad", nil, nil),
+		TotalSessionsDesc:       prometheus.NewDesc("etherpad_toolkit_total_sessions", "The total number of sessions reported by Etherpad", nil, nil),
+		TotalActivePadsDesc:     prometheus.NewDesc("etherpad_toolkit_total_active_pads", "The total number of active pads reported by Etherpad", nil, nil),
+		CacheAgeDesc:            prometheus.NewDesc("etherpad_toolkit_cache_age_seconds", "The time since the last successful collection in the background", nil, nil),
+		LastCollectionErrorDesc: prometheus.NewDesc("etherpad_toolkit_last_collection_error", "Whether the last collection in the background failed", nil, nil),
+		UpDesc:                  prometheus.NewDesc("etherpad_toolkit_up", "Whether the last collection of the pads was successful", nil, nil),
+		ScrapeDurationDesc:      prometheus.NewDesc("etherpad_toolkit_scrape_duration_seconds", "The duration of the last collection", nil, nil),
+		LastSuccessDesc:         prometheus.NewDesc("etherpad_toolkit_last_successful_collection_timestamp_seconds", "The unix timestamp of the last successful collection", nil, nil),
+		apiErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "etherpad_toolkit_api_errors_total",
+			Help: "The number of failed requests to the Etherpad API",
+		}, []string{"method", "code"}),
+	}
+	// an existing callback of the caller is called as well
+	onError := etherpad.OnError
+	etherpad.OnError = func(method string, err error) {
+		pc.countError(method, err)
+		if onError != nil {
+			onError(method, err)
+		}
+	}
+
+	return pc
+}
+
+// countError increments the error counter for the API method. The code is the error code of Etherpad or "request" if
+// the request failed.
+func (pc *PadCollector) countError(method string, err error) {
+	code := "request"
+	var apiErr *pkg.APIError
+	if errors.As(err, &apiErr) {
+		code = strconv.Itoa(apiErr.Code)
 	}
+
+	pc.apiErrors.WithLabelValues(method, code).Inc()
+}
+
+// Run collects the data in the given interval until the context is done. The metrics are served from the cache
+// after Run was called.
+func (pc *PadCollector) Run(ctx context.Context, interval time.Duration) {
+	pc.mu.Lock()
+	pc.background = true
+	pc.mu.Unlock()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		pc.Refresh()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Refresh collects the data and updates the cache. The previous data is kept if the collection fails.
+func (pc *PadCollector) Refresh() {
+	start := time.Now()
+	snap, err := pc.gather()
+
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	pc.lastErr = err
+	pc.duration = time.Since(start)
+	if err != nil {
+		log.WithError(err).Error("failed to refresh pad metrics")
+		return
+	}
+	pc.cache = snap
+	pc.updated = time.Now()
+	log.WithField("took", time.Since(start)).Debug("refreshed pad metrics")
 }
 
 func (pc *PadCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- pc.PadGaugeDesc
+	if pc.Expiration != nil {
+		ch <- pc.PadAgeDesc
+		ch <- pc.PadRevisionsDesc
+		ch <- pc.PadExpiredDesc
+	}
+	if pc.Users {
+		ch <- pc.PadUsersDesc
+		ch <- pc.TopPadUsersDesc
+	}
+	ch <- pc.TotalPadsDesc
+	ch <- pc.TotalSessionsDesc
+	ch <- pc.TotalActivePadsDesc
+	ch <- pc.CacheAgeDesc
+	ch <- pc.LastCollectionErrorDesc
+	ch <- pc.UpDesc
+	ch <- pc.ScrapeDurationDesc
+	ch <- pc.LastSuccessDesc
+	pc.apiErrors.Describe(ch)
 }
 
 func (pc *PadCollector) Collect(ch chan<- prometheus.Metric) {
+	pc.mu.RLock()
+	background := pc.background
+	pc.mu.RUnlock()
+
+	if !background {
+		pc.Refresh()
+	}
+
+	pc.mu.RLock()
+	defer pc.mu.RUnlock()
+
+	if pc.cache != nil && (background || pc.lastErr == nil) {
+		pc.send(ch, pc.cache)
+	}
+
+	up := 1.0
+	if pc.lastErr != nil {
+		up = 0
+	}
+	ch <- prometheus.MustNewConstMetric(pc.UpDesc, prometheus.GaugeValue, up)
+	ch <- prometheus.MustNewConstMetric(pc.ScrapeDurationDesc, prometheus.GaugeValue, pc.duration.Seconds())
+	if !pc.updated.IsZero() {
+		ch <- prometheus.MustNewConstMetric(pc.LastSuccessDesc, prometheus.GaugeValue, float64(pc.updated.Unix()))
+	}
+	pc.apiErrors.Collect(ch)
+
+	if !background {
+		return
+	}
+
+	if pc.cache != nil {
+		ch <- prometheus.MustNewConstMetric(pc.CacheAgeDesc, prometheus.GaugeValue, time.Since(pc.updated).Seconds())
+	}
+	ch <- prometheus.MustNewConstMetric(pc.LastCollectionErrorDesc, prometheus.GaugeValue, 1-up)
+}
+
+// gather fetches the pads and, if an expiration is configured, the details of all pads.
+func (pc *PadCollector) gather() (*snapshot, error) {
 	allPads, err := pc.etherpad.ListAllPads()
 	if err != nil {
-		log.WithError(err).Error("failed to list all allPads")
-		return
+		return nil, err
 	}
 
-	sorted := helper.GroupPadsBySuffixes(allPads, pc.suffixes)
+	snap := &snapshot{pads: helper.GroupPadsBySuffixes(allPads, pc.suffixes)}
+	if pc.Expiration != nil {
+		snap.details = pc.collectDetails(allPads)
+	}
+	if pc.Users {
+		snap.users, snap.top = pc.collectUsers(snap.pads)
+	}
 
-	for suffix, pads := range sorted {
+	stats, err := pc.etherpad.GetStats()
+	if err != nil {
+		log.WithError(err).Debug("failed to get stats")
+	} else {
+		snap.stats = &stats
+	}
+
+	return snap, nil
+}
+
+func (pc *PadCollector) send(ch chan<- prometheus.Metric, snap *snapshot) {
+	for suffix, pads := range snap.pads {
 		ch <- prometheus.MustNewConstMetric(
 			pc.PadGaugeDesc,
 			prometheus.GaugeValue,
@@ -42,4 +262,140 @@ func (pc *PadCollector) Collect(ch chan<- prometheus.Metric) {
 			suffix,
 		)
 	}
+
+	for suffix, details := range snap.details {
+		ch <- newHistogram(pc.PadAgeDesc, ageBuckets, details.ages, suffix)
+		ch <- newHistogram(pc.PadRevisionsDesc, revisionBuckets, details.revisions, suffix)
+		ch <- prometheus.MustNewConstMetric(
+			pc.PadExpiredDesc,
+			prometheus.GaugeValue,
+			float64(details.expired),
+			suffix,
+		)
+	}
+
+	for suffix, users := range snap.users {
+		ch <- prometheus.MustNewConstMetric(pc.PadUsersDesc, prometheus.GaugeValue, float64(users), suffix)
+	}
+	for _, top := range snap.top {
+		ch <- prometheus.MustNewConstMetric(pc.TopPadUsersDesc, prometheus.GaugeValue, float64(top.users), top.pad)
+	}
+
+	if snap.stats != nil {
+		ch <- prometheus.MustNewConstMetric(pc.TotalPadsDesc, prometheus.GaugeValue, float64(snap.stats.TotalPads))
+		ch <- prometheus.MustNewConstMetric(pc.TotalSessionsDesc, prometheus.GaugeValue, float64(snap.stats.TotalSessions))
+		ch <- prometheus.MustNewConstMetric(pc.TotalActivePadsDesc, prometheus.GaugeValue, float64(snap.stats.TotalActivePads))
+	}
+}
+
+// collectDetails fetches the last edited time and the revisions of all pads and groups them by expiration.
+func (pc *PadCollector) collectDetails(allPads []string) map[string]*padDetails {
+	now := time.Now()
+	result := make(map[string]*padDetails)
+	suffixes := make(map[string]string)
+	for suffix, pads := range helper.GroupPadsByExpiration(allPads, pc.Expiration) {
+		result[suffix] = &padDetails{}
+		for _, pad := range pads {
+			suffixes[pad] = suffix
+		}
+	}
+
+	var mu sync.Mutex
+	pc.forEachPad(padsOf(suffixes), func(pad string) {
+		revisions, err := pc.etherpad.GetRevisionsCount(pad)
+		if err != nil {
+			log.WithError(err).WithField("pad", pad).Error("failed to get revisions count")
+			return
+		}
+		lastEdited, err := pc.etherpad.GetLastEdited(pad)
+		if err != nil {
+			log.WithError(err).WithField("pad", pad).Error("failed to get last edited time")
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		details := result[suffixes[pad]]
+		details.ages = append(details.ages, now.Sub(lastEdited).Seconds())
+		details.revisions = append(details.revisions, float64(revisions))
+		if pc.Expiration.IsExpired(pad, lastEdited, revisions, now) {
+			details.expired++
+		}
+	})
+
+	return result
+}
+
+// collectUsers fetches the connected users of all pads and sums them up per suffix. Returns the pads with the most
+// users as well.
+func (pc *PadCollector) collectUsers(sorted map[string][]string) (map[string]int, []padUsers) {
+	result := make(map[string]int)
+	suffixes := make(map[string]string)
+	for suffix, pads := range sorted {
+		result[suffix] = 0
+		for _, pad := range pads {
+			suffixes[pad] = suffix
+		}
+	}
+
+	var mu sync.Mutex
+	var all []padUsers
+	pc.forEachPad(padsOf(suffixes), func(pad string) {
+		users, err := pc.etherpad.PadUsersCount(pad)
+		if err != nil {
+			log.WithError(err).WithField("pad", pad).Error("failed to get users count")
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		result[suffixes[pad]] += users
+		if users > 0 {
+			all = append(all, padUsers{pad: pad, users: users})
+		}
+	})
+
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].users == all[j].users {
+			return all[i].pad < all[j].pad
+		}
+		return all[i].users > all[j].users
+	})
+	if len(all) > pc.TopPads {
+		all = all[:pc.TopPads]
+	}
+
+	return result, all
+}
+
+// forEachPad calls the function for every pad with the configured concurrency.
+func (pc *PadCollector) forEachPad(pads []string, fn func(pad string)) {
+	helper.ForEach(pc.Concurrency, len(pads), func(i int) {
+		fn(pads[i])
+	})
+}
+
+func padsOf(suffixes map[string]string) []string {
+	pads := make([]string, 0, len(suffixes))
+	for pad := range suffixes {
+		pads = append(pads, pad)
+	}
+
+	return pads
+}
+
+// newHistogram returns a constant histogram for the observed values.
+func newHistogram(desc *prometheus.Desc, buckets, values []float64, labelValues ...string) prometheus.Metric {
+	counts := make(map[float64]uint64, len(buckets))
+	var sum float64
+	for _, value := range values {
+		sum += value
+		for _, bucket := range buckets {
+			if value <= bucket {
+				counts[bucket]++
+			}
+		}
+	}
+
+	return prometheus.MustNewConstHistogram(desc, uint64(len(values)), sum, counts, labelValues...)
 }
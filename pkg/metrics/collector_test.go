@@ -0,0 +1,188 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/helper"
+)
+
+func etherpadServer() *httptest.Server {
+	lastEdited := map[string]time.Duration{
+		"pad":      2 * time.Hour,
+		"pad2":     800 * time.Hour,
+		"pad-keep": 48 * time.Hour,
+		"pad-temp": 48 * time.Hour,
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		padID := r.URL.Query().Get("padID")
+
+		w.WriteHeader(http.StatusOK)
+		switch method {
+		case "listAllPads":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"padIDs": ["pad", "pad2", "pad-keep", "pad-temp"]}}`))
+		case "getRevisionsCount":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"revisions": 20}}`))
+		case "padUsersCount":
+			users := map[string]int{"pad": 3, "pad2": 1, "pad-keep": 2}[padID]
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"code": 0, "message":"ok", "data": {"padUsersCount": %d}}`, users)))
+		case "getStats":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"totalPads": 4, "totalSessions": 6, "totalActivePads": 3}}`))
+		case "getLastEdited":
+			edited := time.Now().Add(-lastEdited[padID]).UnixMilli()
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"code": 0, "message":"ok", "data": {"lastEdited": %d}}`, edited)))
+		default:
+			_, _ = w.Write([]byte(`{"code": 1, "message":"unknown", "data": null}`))
+		}
+	}))
+}
+
+func TestPadCollector_Collect(t *testing.T) {
+	ts := etherpadServer()
+	defer ts.Close()
+
+	collector := NewPadCollector(pkg.NewEtherpadClient(ts.URL, ""), []string{"keep", "temp"})
+
+	expected := `
+# HELP etherpad_toolkit_pads The current number of pads
+# TYPE etherpad_toolkit_pads gauge
+etherpad_toolkit_pads{suffix="default"} 2
+etherpad_toolkit_pads{suffix="keep"} 1
+etherpad_toolkit_pads{suffix="temp"} 1
+`
+	err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "etherpad_toolkit_pads")
+	assert.Nil(t, err)
+}
+
+func TestPadCollector_Collect_Expiration(t *testing.T) {
+	ts := etherpadServer()
+	defer ts.Close()
+
+	exp, err := helper.ParsePadExpiration("default:720h,temp:24h,keep:8760h")
+	assert.Nil(t, err)
+
+	collector := NewPadCollector(pkg.NewEtherpadClient(ts.URL, ""), []string{"keep", "temp"})
+	collector.Expiration = exp
+
+	expected := `
+# HELP etherpad_toolkit_pads_expired The current number of pads which are past their expiration
+# TYPE etherpad_toolkit_pads_expired gauge
+etherpad_toolkit_pads_expired{suffix="default"} 1
+etherpad_toolkit_pads_expired{suffix="keep"} 0
+etherpad_toolkit_pads_expired{suffix="temp"} 1
+`
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expected), "etherpad_toolkit_pads_expired")
+	assert.Nil(t, err)
+
+	assert.Equal(t, 3, testutil.CollectAndCount(collector, "etherpad_toolkit_pad_age_seconds"))
+	assert.Equal(t, 3, testutil.CollectAndCount(collector, "etherpad_toolkit_pad_revisions"))
+}
+
+func TestPadCollector_Collect_Users(t *testing.T) {
+	ts := etherpadServer()
+	defer ts.Close()
+
+	collector := NewPadCollector(pkg.NewEtherpadClient(ts.URL, ""), []string{"keep", "temp"})
+	collector.Users = true
+	collector.TopPads = 2
+
+	expected := `
+# HELP etherpad_toolkit_pad_users The current number of users connected to pads
+# TYPE etherpad_toolkit_pad_users gauge
+etherpad_toolkit_pad_users{suffix="default"} 4
+etherpad_toolkit_pad_users{suffix="keep"} 2
+etherpad_toolkit_pad_users{suffix="temp"} 0
+# HELP etherpad_toolkit_top_pad_users The current number of users connected to the pads with the most users
+# TYPE etherpad_toolkit_top_pad_users gauge
+etherpad_toolkit_top_pad_users{pad="pad"} 3
+etherpad_toolkit_top_pad_users{pad="pad-keep"} 2
+# HELP etherpad_toolkit_total_sessions The total number of sessions reported by Etherpad
+# TYPE etherpad_toolkit_total_sessions gauge
+etherpad_toolkit_total_sessions 6
+`
+	err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "etherpad_toolkit_pad_users", "etherpad_toolkit_top_pad_users", "etherpad_toolkit_total_sessions")
+	assert.Nil(t, err)
+}
+
+func TestPadCollector_Run(t *testing.T) {
+	ts := etherpadServer()
+
+	collector := NewPadCollector(pkg.NewEtherpadClient(ts.URL, ""), []string{"keep", "temp"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go collector.Run(ctx, time.Hour)
+
+	assert.Eventually(t, func() bool {
+		return testutil.CollectAndCount(collector, "etherpad_toolkit_cache_age_seconds") == 1
+	}, time.Second, 10*time.Millisecond)
+
+	expected := `
+# HELP etherpad_toolkit_last_collection_error Whether the last collection in the background failed
+# TYPE etherpad_toolkit_last_collection_error gauge
+etherpad_toolkit_last_collection_error 0
+`
+	err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "etherpad_toolkit_last_collection_error")
+	assert.Nil(t, err)
+
+	ts.Close()
+	collector.Refresh()
+
+	expected = `
+# HELP etherpad_toolkit_last_collection_error Whether the last collection in the background failed
+# TYPE etherpad_toolkit_last_collection_error gauge
+etherpad_toolkit_last_collection_error 1
+`
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expected), "etherpad_toolkit_last_collection_error")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, testutil.CollectAndCount(collector, "etherpad_toolkit_pads"))
+}
+
+func TestPadCollector_Collect_SelfMetrics(t *testing.T) {
+	ts := etherpadServer()
+
+	var methods []string
+	etherpad := pkg.NewEtherpadClient(ts.URL, "")
+	etherpad.OnError = func(method string, err error) {
+		methods = append(methods, method)
+	}
+	collector := NewPadCollector(etherpad, []string{"keep", "temp"})
+
+	expected := `
+# HELP etherpad_toolkit_up Whether the last collection of the pads was successful
+# TYPE etherpad_toolkit_up gauge
+etherpad_toolkit_up 1
+`
+	err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "etherpad_toolkit_up")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, testutil.CollectAndCount(collector, "etherpad_toolkit_scrape_duration_seconds"))
+	assert.Equal(t, 1, testutil.CollectAndCount(collector, "etherpad_toolkit_last_successful_collection_timestamp_seconds"))
+
+	ts.Close()
+
+	expected = `
+# HELP etherpad_toolkit_api_errors_total The number of failed requests to the Etherpad API
+# TYPE etherpad_toolkit_api_errors_total counter
+etherpad_toolkit_api_errors_total{code="request",method="listAllPads"} 1
+# HELP etherpad_toolkit_up Whether the last collection of the pads was successful
+# TYPE etherpad_toolkit_up gauge
+etherpad_toolkit_up 0
+`
+	err = testutil.CollectAndCompare(collector, strings.NewReader(expected), "etherpad_toolkit_up", "etherpad_toolkit_api_errors_total")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, testutil.CollectAndCount(collector, "etherpad_toolkit_pads"))
+	assert.Equal(t, 1, testutil.CollectAndCount(collector, "etherpad_toolkit_last_successful_collection_timestamp_seconds"))
+
+	// the callback of the caller is kept
+	assert.Contains(t, methods, "listAllPads")
+}
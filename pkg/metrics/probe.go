@@ -0,0 +1,121 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/helper"
+	"gopkg.in/yaml.v3"
+)
+
+// Target is a Etherpad instance which can be scraped via the probe endpoint.
+type Target struct {
+	Name       string   `yaml:"name"`
+	URL        string   `yaml:"url"`
+	APIKey     string   `yaml:"apikey"`
+	Suffixes   []string `yaml:"suffixes"`
+	Expiration string   `yaml:"expiration"`
+	Users      bool     `yaml:"users"`
+	TopPads    int      `yaml:"users_top"`
+}
+
+// SuffixesOr returns the suffixes of the target or the default suffixes if the target has none.
+func (t Target) SuffixesOr(suffixes []string) []string {
+	if len(t.Suffixes) == 0 {
+		return suffixes
+	}
+
+	return t.Suffixes
+}
+
+// ProbeConfig contains the targets for the probe endpoint.
+type ProbeConfig struct {
+	Targets []Target `yaml:"targets"`
+}
+
+// LoadProbeConfig reads the targets from a YAML file.
+func LoadProbeConfig(path string) (*ProbeConfig, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var config ProbeConfig
+	if err = yaml.Unmarshal(b, &config); err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	for _, target := range config.Targets {
+		if target.Name == "" || target.URL == "" {
+			return nil, errors.New("name and url are required for every target")
+		}
+		if names[target.Name] {
+			return nil, fmt.Errorf("duplicate target: %s", target.Name)
+		}
+		names[target.Name] = true
+	}
+
+	return &config, nil
+}
+
+// ProbeHandler serves the metrics of a single target, selected by the "target" query parameter.
+type ProbeHandler struct {
+	collectors map[string]*PadCollector
+}
+
+// NewProbeHandler returns a instance of ProbeHandler with a PadCollector for every target. The default suffixes are
+// used for targets without suffixes.
+func NewProbeHandler(config *ProbeConfig, suffixes []string, concurrency int) (*ProbeHandler, error) {
+	collectors := make(map[string]*PadCollector)
+	for _, target := range config.Targets {
+		collector := NewPadCollector(pkg.NewEtherpadClient(target.URL, target.APIKey), target.SuffixesOr(suffixes))
+		collector.Concurrency = concurrency
+		collector.Users = target.Users
+		collector.TopPads = target.TopPads
+		if target.Expiration != "" {
+			exp, err := helper.ParsePadExpiration(target.Expiration)
+			if err != nil {
+				return nil, fmt.Errorf("target %s: %w", target.Name, err)
+			}
+			collector.Expiration = exp
+		}
+
+		collectors[target.Name] = collector
+	}
+
+	return &ProbeHandler{collectors: collectors}, nil
+}
+
+// Run collects the data of all targets in the background.
+func (ph *ProbeHandler) Run(ctx context.Context, interval time.Duration) {
+	for _, collector := range ph.collectors {
+		go collector.Run(ctx, interval)
+	}
+}
+
+func (ph *ProbeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("target")
+	if name == "" {
+		http.Error(w, "target parameter is missing", http.StatusBadRequest)
+		return
+	}
+
+	collector, ok := ph.collectors[name]
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown target: %s", name), http.StatusNotFound)
+		return
+	}
+
+	registry := prometheus.NewRegistry()
+	prometheus.WrapRegistererWith(prometheus.Labels{"target": name}, registry).MustRegister(collector)
+
+	promhttp.HandlerFor(registry, promhttp.HandlerOpts{}).ServeHTTP(w, r)
+}
@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestLoadProbeConfig(t *testing.T) {
+	path := writeConfig(t, `
+targets:
+  - name: pad1
+    url: https://pad1.example.org
+    apikey: secret
+    suffixes: [keep, temp]
+  - name: pad2
+    url: https://pad2.example.org
+    users: true
+    users_top: 5
+`)
+
+	config, err := LoadProbeConfig(path)
+	assert.Nil(t, err)
+	assert.Len(t, config.Targets, 2)
+	assert.Equal(t, Target{Name: "pad1", URL: "https://pad1.example.org", APIKey: "secret", Suffixes: []string{"keep", "temp"}}, config.Targets[0])
+	assert.Equal(t, 5, config.Targets[1].TopPads)
+
+	path = writeConfig(t, `
+targets:
+  - name: pad1
+    url: https://pad1.example.org
+  - name: pad1
+    url: https://pad2.example.org
+`)
+	_, err = LoadProbeConfig(path)
+	assert.Error(t, err)
+
+	path = writeConfig(t, `
+targets:
+  - name: pad1
+`)
+	_, err = LoadProbeConfig(path)
+	assert.Error(t, err)
+}
+
+func TestProbeHandler_ServeHTTP(t *testing.T) {
+	ts := etherpadServer()
+	defer ts.Close()
+
+	config := &ProbeConfig{Targets: []Target{{Name: "pad1", URL: ts.URL}}}
+	handler, err := NewProbeHandler(config, []string{"keep", "temp"}, 1)
+	assert.Nil(t, err)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/probe?target=pad1", nil))
+	body, _ := io.ReadAll(rec.Body)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, string(body), `etherpad_toolkit_pads{suffix="keep",target="pad1"} 1`)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/probe?target=unknown", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/probe", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestNewProbeHandler_InvalidExpiration(t *testing.T) {
+	config := &ProbeConfig{Targets: []Target{{Name: "pad1", URL: "http://localhost", Expiration: "temp:1h"}}}
+	_, err := NewProbeHandler(config, []string{"keep", "temp"}, 1)
+	assert.Error(t, err)
+}
+
+func TestTarget_SuffixesOr(t *testing.T) {
+	assert.Equal(t, []string{"keep", "temp"}, Target{}.SuffixesOr([]string{"keep", "temp"}))
+	assert.Equal(t, []string{"public"}, Target{Suffixes: []string{"public"}}.SuffixesOr([]string{"keep", "temp"}))
+}
@@ -0,0 +1,129 @@
+package metrics
+
+import (
+	"errors"
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+	"github.com/systemli/etherpad-toolkit/pkg/purge"
+)
+
+const lastSuccessName = "etherpad_toolkit_purge_last_success_timestamp_seconds"
+
+// NewPurgeRegistry returns a registry with the metrics of a purge run.
+// The timestamp of the last successful run is only set if the run was completed, failures of single pads are counted
+// in the failed pads. A dry run sets neither the success nor the timestamp of the last successful run, so it can not
+// hide a failing purge.
+func NewPurgeRegistry(summary purge.Summary) *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+
+	inspected := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "etherpad_toolkit_purge_pads_inspected",
+		Help: "The number of pads inspected in the last purge run",
+	}, []string{"suffix"})
+	deleted := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "etherpad_toolkit_purge_pads_deleted",
+		Help: "The number of pads deleted in the last purge run",
+	}, []string{"suffix"})
+	failed := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "etherpad_toolkit_purge_pads_failed",
+		Help: "The number of pads which failed in the last purge run",
+	}, []string{"suffix"})
+	duration := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "etherpad_toolkit_purge_duration_seconds",
+		Help: "The duration of the last purge run",
+	})
+	lastRun := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "etherpad_toolkit_purge_last_run_timestamp_seconds",
+		Help: "The unix timestamp of the last purge run",
+	})
+	success := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "etherpad_toolkit_purge_success",
+		Help: "Whether the last purge run was completed",
+	})
+	registry.MustRegister(inspected, deleted, failed, duration, lastRun)
+
+	for suffix, stats := range summary.Groups {
+		inspected.WithLabelValues(suffix).Set(float64(stats.Inspected))
+		deleted.WithLabelValues(suffix).Set(float64(stats.Deleted))
+		failed.WithLabelValues(suffix).Set(float64(stats.Failed))
+	}
+	duration.Set(summary.Duration().Seconds())
+	lastRun.Set(float64(summary.Finish.Unix()))
+
+	if summary.DryRun {
+		return registry
+	}
+
+	registry.MustRegister(success)
+	if summary.Completed() {
+		success.Set(1)
+
+		lastSuccess := prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: lastSuccessName,
+			Help: "The unix timestamp of the last successful purge run",
+		})
+		lastSuccess.Set(float64(summary.Finish.Unix()))
+		registry.MustRegister(lastSuccess)
+	}
+
+	return registry
+}
+
+// PushPurgeMetrics sends the metrics of a purge run to a Prometheus Pushgateway.
+// Metrics which are not part of the run (e.g. the last successful run) are kept in the Pushgateway.
+func PushPurgeMetrics(url, job string, summary purge.Summary) error {
+	return push.New(url, job).Gatherer(NewPurgeRegistry(summary)).Add()
+}
+
+// WritePurgeMetrics writes the metrics of a purge run to a file for the node_exporter textfile collector.
+// The timestamp of the last successful run is taken from the existing file if the run was not completed or a dry run.
+func WritePurgeMetrics(path string, summary purge.Summary) error {
+	registry := NewPurgeRegistry(summary)
+
+	if !summary.Completed() || summary.DryRun {
+		lastSuccess, err := readLastSuccess(path)
+		if err != nil {
+			return err
+		}
+		if lastSuccess != nil {
+			gauge := prometheus.NewGauge(prometheus.GaugeOpts{
+				Name: lastSuccessName,
+				Help: "The unix timestamp of the last successful purge run",
+			})
+			gauge.Set(*lastSuccess)
+			registry.MustRegister(gauge)
+		}
+	}
+
+	return prometheus.WriteToTextfile(path, registry)
+}
+
+func readLastSuccess(path string) (*float64, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	parser := expfmt.NewTextParser(model.UTF8Validation)
+	families, err := parser.TextToMetricFamilies(f)
+	if err != nil {
+		return nil, err
+	}
+
+	family, ok := families[lastSuccessName]
+	if !ok || len(family.GetMetric()) == 0 {
+		return nil, nil
+	}
+
+	value := family.GetMetric()[0].GetGauge().GetValue()
+
+	return &value, nil
+}
@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/systemli/etherpad-toolkit/pkg/purge"
+)
+
+var summary = purge.Summary{
+	Start:  time.Unix(1609459200, 0),
+	Finish: time.Unix(1609459290, 0),
+	Groups: map[string]purge.Stats{
+		"default": {Inspected: 10, Deleted: 3, Failed: 1},
+	},
+}
+
+func TestWritePurgeMetrics(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "purge.prom")
+
+	err := WritePurgeMetrics(path, summary)
+	assert.Nil(t, err)
+
+	b, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Contains(t, string(b), `etherpad_toolkit_purge_pads_deleted{suffix="default"} 3`)
+	assert.Contains(t, string(b), `etherpad_toolkit_purge_duration_seconds 90`)
+	assert.Contains(t, string(b), `etherpad_toolkit_purge_success 1`)
+	assert.Contains(t, string(b), `etherpad_toolkit_purge_last_success_timestamp_seconds 1.60945929e+09`)
+
+	failed := purge.Summary{Start: time.Unix(1609545600, 0), Finish: time.Unix(1609545601, 0), Error: "connection refused"}
+	err = WritePurgeMetrics(path, failed)
+	assert.Nil(t, err)
+
+	b, err = os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Contains(t, string(b), `etherpad_toolkit_purge_success 0`)
+	assert.Contains(t, string(b), `etherpad_toolkit_purge_last_run_timestamp_seconds 1.609545601e+09`)
+	assert.Contains(t, string(b), `etherpad_toolkit_purge_last_success_timestamp_seconds 1.60945929e+09`)
+}
+
+func TestPushPurgeMetrics(t *testing.T) {
+	var method, path, body string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		path = r.URL.Path
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	err := PushPurgeMetrics(ts.URL, "etherpad_toolkit_purge", summary)
+	assert.Nil(t, err)
+	assert.Equal(t, http.MethodPost, method)
+	assert.Equal(t, "/metrics/job/etherpad_toolkit_purge", path)
+	assert.NotEmpty(t, body)
+}
+
+func TestWritePurgeMetrics_DryRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "purge.prom")
+
+	err := WritePurgeMetrics(path, summary)
+	assert.Nil(t, err)
+
+	dryRun := purge.Summary{Start: time.Unix(1609545600, 0), Finish: time.Unix(1609545601, 0), DryRun: true}
+	err = WritePurgeMetrics(path, dryRun)
+	assert.Nil(t, err)
+
+	b, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(b), `etherpad_toolkit_purge_success`)
+	assert.Contains(t, string(b), `etherpad_toolkit_purge_last_run_timestamp_seconds 1.609545601e+09`)
+	assert.Contains(t, string(b), `etherpad_toolkit_purge_last_success_timestamp_seconds 1.60945929e+09`)
+}
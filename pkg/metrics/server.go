@@ -0,0 +1,128 @@
+package metrics
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/exporter-toolkit/web"
+	log "github.com/sirupsen/logrus"
+	"github.com/systemli/etherpad-toolkit/pkg"
+)
+
+// Instance is a Etherpad instance which is checked for readiness and listed in the info endpoint.
+type Instance struct {
+	Name     string
+	Etherpad *pkg.Etherpad
+	Suffixes []string
+}
+
+// Server serves the metrics, health, readiness and info endpoints.
+type Server struct {
+	mux       *http.ServeMux
+	version   string
+	instances []Instance
+}
+
+// NewServer returns a instance of Server. The metrics of the default registry are served on /metrics.
+func NewServer(version string, instances []Instance) *Server {
+	s := &Server{
+		mux:       http.NewServeMux(),
+		version:   version,
+		instances: instances,
+	}
+
+	s.mux.Handle("/metrics", promhttp.Handler())
+	s.mux.HandleFunc("/healthz", s.healthz)
+	s.mux.HandleFunc("/readyz", s.readyz)
+	s.mux.HandleFunc("/info", s.info)
+
+	return s
+}
+
+// Handle registers an additional handler for the given pattern.
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) healthz(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// readyz checks if all Etherpad instances are reachable and the API keys are valid.
+func (s *Server) readyz(w http.ResponseWriter, _ *http.Request) {
+	status := http.StatusOK
+	result := make(map[string]string)
+	for _, instance := range s.instances {
+		if err := instance.Etherpad.CheckToken(); err != nil {
+			log.WithError(err).WithField("instance", instance.Name).Warn("etherpad is not ready")
+			result[instance.Name] = err.Error()
+			status = http.StatusServiceUnavailable
+			continue
+		}
+		result[instance.Name] = "ok"
+	}
+
+	writeJSON(w, status, result)
+}
+
+func (s *Server) info(w http.ResponseWriter, _ *http.Request) {
+	type instanceInfo struct {
+		APIVersion string   `json:"apiVersion"`
+		Suffixes   []string `json:"suffixes"`
+	}
+
+	instances := make(map[string]instanceInfo)
+	for _, instance := range s.instances {
+		version, err := instance.Etherpad.GetAPIVersion()
+		if err != nil {
+			log.WithError(err).WithField("instance", instance.Name).Warn("failed to detect api version")
+		}
+		instances[instance.Name] = instanceInfo{APIVersion: version, Suffixes: instance.Suffixes}
+	}
+
+	writeJSON(w, http.StatusOK, struct {
+		Version    string                  `json:"version"`
+		APIVersion string                  `json:"clientApiVersion"`
+		Instances  map[string]instanceInfo `json:"instances"`
+	}{
+		Version:    s.version,
+		APIVersion: pkg.ApiVersion,
+		Instances:  instances,
+	})
+}
+
+// ListenAndServe starts the HTTP server. TLS, client certificates and basic auth are configured with the web config
+// file of the Prometheus exporter-toolkit. The server listens in plain HTTP if the path is empty. Basic auth applies to
+// all endpoints including /healthz and /readyz, so health checks must send the credentials.
+// See: https://github.com/prometheus/exporter-toolkit/blob/master/docs/web-configuration.md
+func ListenAndServe(server *http.Server, webConfigFile string) error {
+	if webConfigFile != "" {
+		if err := web.Validate(webConfigFile); err != nil {
+			return err
+		}
+	}
+
+	systemdSocket := false
+	flags := &web.FlagConfig{
+		WebListenAddresses: &[]string{server.Addr},
+		WebSystemdSocket:   &systemdSocket,
+		WebConfigFile:      &webConfigFile,
+	}
+	logger := slog.New(slog.NewTextHandler(log.StandardLogger().Out, nil))
+
+	return web.ListenAndServe(server, flags, logger)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.WithError(err).Error("failed to write response")
+	}
+}
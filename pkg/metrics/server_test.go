@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/systemli/etherpad-toolkit/pkg"
+)
+
+func TestServer(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Path == "/api" {
+			_, _ = w.Write([]byte(`{"currentVersion":"1.2.15"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": null}`))
+	}))
+	defer ts.Close()
+
+	server := NewServer("1.0.0", []Instance{
+		{Name: "default", Etherpad: pkg.NewEtherpadClient(ts.URL, ""), Suffixes: []string{"keep", "temp"}},
+	})
+
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"default": "ok"}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/info", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var info map[string]interface{}
+	err := json.NewDecoder(rec.Body).Decode(&info)
+	assert.Nil(t, err)
+	assert.Equal(t, "1.0.0", info["version"])
+	assert.Equal(t, map[string]interface{}{
+		"default": map[string]interface{}{"apiVersion": "1.2.15", "suffixes": []interface{}{"keep", "temp"}},
+	}, info["instances"])
+
+	rec = httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestServer_NotReady(t *testing.T) {
+	server := NewServer("1.0.0", []Instance{
+		{Name: "default", Etherpad: pkg.NewEtherpadClient("http://127.0.0.1:0", "")},
+	})
+
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	rec = httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestListenAndServe_InvalidWebConfig(t *testing.T) {
+	path := writeConfig(t, `
+basic_auth_users:
+  admin: not-a-bcrypt-hash
+tls_server_config:
+  cert_file: missing.crt
+`)
+
+	err := ListenAndServe(&http.Server{Addr: "127.0.0.1:0"}, path)
+	assert.Error(t, err)
+}
@@ -0,0 +1,215 @@
+package mirror
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/export"
+	"github.com/systemli/etherpad-toolkit/pkg/helper"
+	"github.com/systemli/etherpad-toolkit/pkg/importer"
+)
+
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+)
+
+// State is the state of a pad on one instance.
+type State struct {
+	Revisions  int
+	LastEdited time.Time
+}
+
+// Change is a pad which is copied to the destination.
+type Change struct {
+	Pad         string
+	Action      string
+	Source      State
+	Destination State
+}
+
+type Mirror struct {
+	source      *pkg.Etherpad
+	destination *pkg.Etherpad
+	importer    *importer.Importer
+
+	// Filter selects the pads to sync. All pads are synced if nil.
+	Filter *helper.PadFilter
+	// Concurrency is the number of pads which are compared and copied in parallel.
+	Concurrency int
+}
+
+// NewMirror returns a instance of Mirror which copies pads from the source to the destination.
+func NewMirror(source, destination *pkg.Etherpad) *Mirror {
+	return &Mirror{
+		source:      source,
+		destination: destination,
+		importer:    importer.NewImporter(destination, true, false),
+		Concurrency: 4,
+	}
+}
+
+// Plan returns the pads which are missing on the destination or newer on the source. A pad is newer if it was edited
+// later or has more revisions at the same time of the last edit. Pads which were edited later on the destination
+// are not changed.
+func (m *Mirror) Plan() ([]Change, error) {
+	pads, err := m.source.ListAllPads()
+	if err != nil {
+		return nil, err
+	}
+	if m.Filter != nil {
+		pads = m.Filter.Apply(pads)
+	}
+
+	destinationPads, err := m.destination.ListAllPads()
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]bool)
+	for _, pad := range destinationPads {
+		existing[pad] = true
+	}
+
+	changes := make([]*Change, len(pads))
+	helper.ForEach(m.Concurrency, len(pads), func(i int) {
+		change, err := m.compare(pads[i], existing[pads[i]])
+		if err != nil {
+			log.WithError(err).WithField("pad", pads[i]).Error("failed to compare pad")
+			return
+		}
+		changes[i] = change
+	})
+
+	var result []Change
+	for _, change := range changes {
+		if change != nil {
+			result = append(result, *change)
+		}
+	}
+
+	return result, nil
+}
+
+// Apply copies the pads with history and chat to the destination and returns the number of failed pads. Updated pads
+// are imported into a temporary pad on the destination which replaces the pad afterwards.
+func (m *Mirror) Apply(changes []Change) int {
+	var mu sync.Mutex
+	failed := 0
+	helper.ForEach(m.Concurrency, len(changes), func(i int) {
+		if err := m.copy(changes[i]); err != nil {
+			log.WithError(err).WithField("pad", changes[i].Pad).Error("failed to sync pad")
+			mu.Lock()
+			failed++
+			mu.Unlock()
+		}
+	})
+
+	return failed
+}
+
+// Sync plans and applies the changes. Only the plan is returned if dryRun is true.
+func (m *Mirror) Sync(dryRun bool) ([]Change, int, error) {
+	changes, err := m.Plan()
+	if err != nil || dryRun {
+		return changes, 0, err
+	}
+
+	return changes, m.Apply(changes), nil
+}
+
+// Run syncs the pads in the interval until the context is done.
+func (m *Mirror) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		changes, failed, err := m.Sync(false)
+		if err != nil {
+			log.WithError(err).Error("failed to sync pads")
+		} else {
+			log.WithFields(log.Fields{"changes": len(changes), "failed": failed}).Info("finished sync")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *Mirror) compare(pad string, exists bool) (*Change, error) {
+	source, err := state(m.source, pad)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return &Change{Pad: pad, Action: ActionCreate, Source: source}, nil
+	}
+
+	destination, err := state(m.destination, pad)
+	if err != nil {
+		return nil, err
+	}
+
+	if destination.LastEdited.After(source.LastEdited) {
+		log.WithField("pad", pad).Warn("pad was edited on the destination, skip")
+		return nil, nil
+	}
+	if source.LastEdited.After(destination.LastEdited) || source.Revisions > destination.Revisions {
+		return &Change{Pad: pad, Action: ActionUpdate, Source: source, Destination: destination}, nil
+	}
+
+	return nil, nil
+}
+
+func (m *Mirror) copy(change Change) error {
+	data, err := m.source.ExportPad(change.Pad, export.FormatEtherpad, pkg.LatestRevision)
+	if err != nil {
+		return err
+	}
+
+	return m.importer.ImportData(change.Pad, export.FileName(change.Pad, export.FormatEtherpad), data, change.Action == ActionUpdate)
+}
+
+func state(ep *pkg.Etherpad, pad string) (State, error) {
+	var err error
+	var s State
+
+	if s.Revisions, err = ep.GetRevisionsCount(pad); err != nil {
+		return s, err
+	}
+	s.LastEdited, err = ep.GetLastEdited(pad)
+
+	return s, err
+}
+
+// WriteChanges writes the changes as table, e.g. for a dry-run.
+func WriteChanges(w io.Writer, changes []Change) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "ACTION\tPAD\tSOURCE\tDESTINATION"); err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		destination := "-"
+		if change.Action == ActionUpdate {
+			destination = change.Destination.String()
+		}
+		if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", change.Action, change.Pad, change.Source.String(), destination); err != nil {
+			return err
+		}
+	}
+
+	return tw.Flush()
+}
+
+func (s State) String() string {
+	return fmt.Sprintf("%d revisions, %s", s.Revisions, s.LastEdited.Format(time.RFC3339))
+}
@@ -0,0 +1,135 @@
+package mirror
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/etherpadtest"
+	"github.com/systemli/etherpad-toolkit/pkg/helper"
+)
+
+type instance struct {
+	mu         sync.Mutex
+	calls      []string
+	pads       string
+	lastEdited map[string]string
+	revisions  map[string]string
+}
+
+func (in *instance) handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(r.URL.Path, "/")
+		if parts[1] == "p" {
+			in.record(parts[3] + " " + parts[2])
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": null}`))
+			return
+		}
+
+		pad := r.FormValue("padID")
+		switch parts[len(parts)-1] {
+		case "listAllPads":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"padIDs": [` + in.pads + `]}}`))
+		case "getRevisionsCount":
+			if in.revisions[pad] == "" {
+				_, _ = w.Write([]byte(`{"code": 1, "message":"padID does not exist", "data": null}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"revisions": ` + in.revisions[pad] + `}}`))
+		case "getLastEdited":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"lastEdited": ` + in.lastEdited[pad] + `}}`))
+		default:
+			in.record(parts[len(parts)-1] + " " + pad + r.FormValue("sourceID"))
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": null}`))
+		}
+	}
+}
+
+func (in *instance) record(call string) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	in.calls = append(in.calls, etherpadtest.Mask(call))
+}
+
+func servers() (*instance, *httptest.Server, *instance, *httptest.Server) {
+	source := &instance{
+		pads:       `"pad1", "pad2", "pad3", "pad4", "pad5", "pad6-temp"`,
+		lastEdited: map[string]string{"pad1": "1000", "pad2": "2000", "pad3": "1000", "pad4": "1000", "pad5": "1000", "pad6-temp": "1000"},
+		revisions:  map[string]string{"pad1": "1", "pad2": "2", "pad3": "2", "pad4": "1", "pad5": "3", "pad6-temp": "1"},
+	}
+	destination := &instance{
+		pads:       `"pad2", "pad3", "pad4", "pad5"`,
+		lastEdited: map[string]string{"pad2": "1000", "pad3": "1000", "pad4": "2000", "pad5": "1000"},
+		revisions:  map[string]string{"pad2": "1", "pad3": "2", "pad4": "1", "pad5": "2"},
+	}
+
+	return source, httptest.NewServer(source.handler()), destination, httptest.NewServer(destination.handler())
+}
+
+func TestMirror_Plan(t *testing.T) {
+	_, src, _, dst := servers()
+	defer src.Close()
+	defer dst.Close()
+
+	mirror := NewMirror(pkg.NewEtherpadClient(src.URL, ""), pkg.NewEtherpadClient(dst.URL, ""))
+	mirror.Filter = &helper.PadFilter{Regex: regexp.MustCompile(`^pad\d$`)}
+
+	changes, err := mirror.Plan()
+	assert.Nil(t, err)
+	assert.Len(t, changes, 3)
+	assert.Equal(t, Change{Pad: "pad1", Action: ActionCreate, Source: State{Revisions: 1, LastEdited: time.UnixMilli(1000)}}, changes[0])
+	assert.Equal(t, "pad2", changes[1].Pad)
+	assert.Equal(t, ActionUpdate, changes[1].Action)
+	assert.Equal(t, "pad5", changes[2].Pad)
+
+	var b bytes.Buffer
+	assert.Nil(t, WriteChanges(&b, changes[:1]))
+	assert.True(t, strings.HasPrefix(b.String(), "ACTION  PAD   SOURCE"))
+	assert.Contains(t, b.String(), "create  pad1  1 revisions, "+time.UnixMilli(1000).Format(time.RFC3339)+"  -\n")
+}
+
+func TestMirror_Sync(t *testing.T) {
+	source, src, destination, dst := servers()
+	defer src.Close()
+	defer dst.Close()
+
+	mirror := NewMirror(pkg.NewEtherpadClient(src.URL, ""), pkg.NewEtherpadClient(dst.URL, ""))
+
+	changes, failed, err := mirror.Sync(true)
+	assert.Nil(t, err)
+	assert.Len(t, changes, 4)
+	assert.Equal(t, 0, failed)
+	assert.Empty(t, source.calls)
+	assert.Empty(t, destination.calls)
+
+	changes, failed, err = mirror.Sync(false)
+	assert.Nil(t, err)
+	assert.Len(t, changes, 4)
+	assert.Equal(t, 0, failed)
+
+	sort.Strings(source.calls)
+	sort.Strings(destination.calls)
+	assert.Equal(t, []string{"export pad1", "export pad2", "export pad5", "export pad6-temp"}, source.calls)
+	assert.Equal(t, []string{"import pad1", "import pad2-import-tmp-*", "import pad5-import-tmp-*", "import pad6-temp", "movePad pad2-import-tmp-*", "movePad pad5-import-tmp-*"}, destination.calls)
+}
+
+func TestMirror_Run(t *testing.T) {
+	_, src, destination, dst := servers()
+	defer src.Close()
+	defer dst.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	NewMirror(pkg.NewEtherpadClient(src.URL, ""), pkg.NewEtherpadClient(dst.URL, "")).Run(ctx, time.Hour)
+	assert.Len(t, destination.calls, 6)
+}
@@ -0,0 +1,71 @@
+package notify
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/systemli/etherpad-toolkit/pkg/purge"
+)
+
+// Mail sends the summary as plain text mail via SMTP.
+type Mail struct {
+	addr     string
+	from     string
+	to       []string
+	template *template.Template
+
+	// Subject of the mail.
+	Subject string
+	// Username and Password are used for PLAIN authentication if the username is set.
+	Username string
+	Password string
+}
+
+// NewMail returns a instance of Mail. The addr has the format "host:port".
+func NewMail(addr, from string, to []string, tmpl *template.Template) *Mail {
+	return &Mail{
+		addr:     addr,
+		from:     from,
+		to:       to,
+		template: tmpl,
+		Subject:  "Etherpad purge summary",
+	}
+}
+
+// Notify sends the summary to all recipients.
+func (m *Mail) Notify(summary purge.Summary) error {
+	text, err := Render(m.template, summary)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.Username != "" {
+		host, _, err := net.SplitHostPort(m.addr)
+		if err != nil {
+			return err
+		}
+		auth = smtp.PlainAuth("", m.Username, m.Password, host)
+	}
+
+	return smtp.SendMail(m.addr, auth, m.from, m.to, m.message(text, time.Now()))
+}
+
+func (m *Mail) message(text string, date time.Time) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", m.from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(m.to, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", m.Subject)
+	fmt.Fprintf(&buf, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(text, "\n", "\r\n"))
+
+	return buf.Bytes()
+}
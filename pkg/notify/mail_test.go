@@ -0,0 +1,33 @@
+package notify
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMail_message(t *testing.T) {
+	mail := NewMail("localhost:25", "etherpad@example.org", []string{"admin@example.org", "ops@example.org"}, nil)
+
+	message := mail.message("line1\nline2\n", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, "From: etherpad@example.org\r\n"+
+		"To: admin@example.org, ops@example.org\r\n"+
+		"Subject: Etherpad purge summary\r\n"+
+		"Date: Fri, 01 Jan 2021 00:00:00 +0000\r\n"+
+		"MIME-Version: 1.0\r\n"+
+		"Content-Type: text/plain; charset=UTF-8\r\n"+
+		"\r\n"+
+		"line1\r\nline2\r\n", string(message))
+}
+
+func TestMail_Notify_Error(t *testing.T) {
+	tmpl, err := ParseTemplate("")
+	assert.Nil(t, err)
+
+	mail := NewMail("127.0.0.1:0", "etherpad@example.org", []string{"admin@example.org"}, tmpl)
+	mail.Username = "user"
+
+	err = mail.Notify(summary)
+	assert.Error(t, err)
+}
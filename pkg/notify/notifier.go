@@ -0,0 +1,50 @@
+package notify
+
+import (
+	"bytes"
+	"os"
+	"text/template"
+
+	"github.com/systemli/etherpad-toolkit/pkg/purge"
+)
+
+// DefaultTemplate is the template for the message body if no custom template is configured.
+const DefaultTemplate = `Etherpad purge {{if .DryRun}}(dry-run) {{end}}{{if .Successful}}finished{{else}}failed{{end}} in {{.Duration}}
+{{- if .Error}}
+Error: {{.Error}}
+{{- end}}
+{{range $suffix, $stats := .Groups}}
+{{$suffix}}: {{$stats.Inspected}} inspected, {{$stats.Deleted}} deleted, {{$stats.Failed}} failed
+{{- end}}
+{{with .Total}}
+Total: {{.Inspected}} inspected, {{.Deleted}} deleted, {{.Failed}} failed{{end}}
+`
+
+// Notifier sends the summary of a purge run.
+type Notifier interface {
+	Notify(summary purge.Summary) error
+}
+
+// ParseTemplate parses the template file. Returns the default template if the path is empty.
+func ParseTemplate(path string) (*template.Template, error) {
+	if path == "" {
+		return template.New("default").Parse(DefaultTemplate)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return template.New(path).Parse(string(b))
+}
+
+// Render executes the template with the summary.
+func Render(tmpl *template.Template, summary purge.Summary) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, summary); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,51 @@
+package notify
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/systemli/etherpad-toolkit/pkg/purge"
+)
+
+var summary = purge.Summary{
+	Start:  time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+	Finish: time.Date(2021, 1, 1, 0, 1, 30, 0, time.UTC),
+	Groups: map[string]purge.Stats{
+		"default": {Inspected: 10, Deleted: 3, Failed: 1},
+		"keep":    {Inspected: 5, Deleted: 1},
+	},
+}
+
+func TestRender(t *testing.T) {
+	tmpl, err := ParseTemplate("")
+	assert.Nil(t, err)
+
+	text, err := Render(tmpl, summary)
+	assert.Nil(t, err)
+	assert.Equal(t, `Etherpad purge failed in 1m30s
+
+default: 10 inspected, 3 deleted, 1 failed
+keep: 5 inspected, 1 deleted, 0 failed
+
+Total: 15 inspected, 4 deleted, 1 failed
+`, text)
+}
+
+func TestParseTemplate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "template.txt")
+	err := os.WriteFile(path, []byte("{{.Total.Deleted}} pads deleted"), 0o600)
+	assert.Nil(t, err)
+
+	tmpl, err := ParseTemplate(path)
+	assert.Nil(t, err)
+
+	text, err := Render(tmpl, summary)
+	assert.Nil(t, err)
+	assert.Equal(t, "4 pads deleted", text)
+
+	_, err = ParseTemplate(filepath.Join(t.TempDir(), "missing.txt"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,89 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"text/template"
+
+	"github.com/systemli/etherpad-toolkit/pkg/purge"
+)
+
+const (
+	FormatGeneric = "generic"
+	FormatMatrix  = "matrix"
+	FormatSlack   = "slack"
+)
+
+// Webhook posts the summary as JSON to an URL.
+type Webhook struct {
+	url      string
+	format   string
+	template *template.Template
+	Client   *http.Client
+}
+
+// NewWebhook returns a instance of Webhook. The format defines the payload and is one of "generic", "matrix" or "slack".
+func NewWebhook(url, format string, tmpl *template.Template) (*Webhook, error) {
+	switch format {
+	case FormatGeneric, FormatMatrix, FormatSlack:
+	default:
+		return nil, fmt.Errorf("unknown webhook format: %s", format)
+	}
+
+	return &Webhook{
+		url:      url,
+		format:   format,
+		template: tmpl,
+		Client:   &http.Client{},
+	}, nil
+}
+
+// Notify sends the summary to the webhook.
+func (w *Webhook) Notify(summary purge.Summary) error {
+	text, err := Render(w.template, summary)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(w.payload(text, summary))
+	if err != nil {
+		return err
+	}
+
+	res, err := w.Client.Post(w.url, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("unexpected status code: %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+func (w *Webhook) payload(text string, summary purge.Summary) interface{} {
+	switch w.format {
+	case FormatMatrix:
+		return map[string]string{"text": text, "format": "plain", "displayName": "etherpad-toolkit"}
+	case FormatSlack:
+		return map[string]string{"text": text}
+	}
+
+	return struct {
+		Text            string        `json:"text"`
+		Successful      bool          `json:"successful"`
+		DurationSeconds float64       `json:"durationSeconds"`
+		Total           purge.Stats   `json:"total"`
+		Summary         purge.Summary `json:"summary"`
+	}{
+		Text:            text,
+		Successful:      summary.Successful(),
+		DurationSeconds: summary.Duration().Seconds(),
+		Total:           summary.Total(),
+		Summary:         summary,
+	}
+}
@@ -0,0 +1,67 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWebhook(t *testing.T) {
+	tmpl, err := ParseTemplate("")
+	assert.Nil(t, err)
+
+	_, err = NewWebhook("http://localhost", "unknown", tmpl)
+	assert.Error(t, err)
+}
+
+func TestWebhook_Notify(t *testing.T) {
+	tmpl, err := ParseTemplate("")
+	assert.Nil(t, err)
+
+	for _, format := range []string{FormatGeneric, FormatMatrix, FormatSlack} {
+		var payload map[string]interface{}
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		webhook, err := NewWebhook(ts.URL, format, tmpl)
+		assert.Nil(t, err)
+
+		err = webhook.Notify(summary)
+		assert.Nil(t, err)
+		assert.Contains(t, payload["text"], "Total: 15 inspected, 4 deleted, 1 failed")
+
+		switch format {
+		case FormatGeneric:
+			assert.Equal(t, false, payload["successful"])
+			assert.Equal(t, 90.0, payload["durationSeconds"])
+		case FormatMatrix:
+			assert.Equal(t, "plain", payload["format"])
+		case FormatSlack:
+			assert.Len(t, payload, 1)
+		}
+
+		ts.Close()
+	}
+}
+
+func TestWebhook_Notify_Error(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	tmpl, err := ParseTemplate("")
+	assert.Nil(t, err)
+
+	webhook, err := NewWebhook(ts.URL, FormatSlack, tmpl)
+	assert.Nil(t, err)
+
+	err = webhook.Notify(summary)
+	assert.Error(t, err)
+}
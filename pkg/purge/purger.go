@@ -6,6 +6,8 @@ import (
 
 	log "github.com/sirupsen/logrus"
 	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/chat"
+	"github.com/systemli/etherpad-toolkit/pkg/compact"
 	"github.com/systemli/etherpad-toolkit/pkg/helper"
 )
 
@@ -13,6 +15,13 @@ type Purger struct {
 	etherpad   *pkg.Etherpad
 	expiration helper.PadExpiration
 	dryRun     bool
+
+	// DefaultPadText is the text Etherpad puts into new pads. Pads which contain only this text are treated as empty.
+	DefaultPadText string
+	// ChatRetention removes old chat messages of pads which are not deleted.
+	ChatRetention *chat.Retention
+	// Compactor removes the history of pads which are not deleted but exceed the revision threshold.
+	Compactor *compact.Compactor
 }
 
 // NewPurger returns a instance of Purger.
@@ -25,32 +34,47 @@ func NewPurger(ep *pkg.Etherpad, exp helper.PadExpiration, dryRun bool) *Purger
 }
 
 // PurgePads loops over a sorted map of pads and removes pads which are not edited for some times.
-func (p *Purger) PurgePads(concurrency int) {
+// Returns a summary of the run.
+func (p *Purger) PurgePads(concurrency int) Summary {
+	summary := Summary{Start: time.Now(), DryRun: p.dryRun, Groups: make(map[string]Stats)}
+
 	pads, err := p.etherpad.ListAllPads()
 	if err != nil {
 		log.WithError(err).Error("failed to list all pads")
-		return
+		summary.Error = err.Error()
+		summary.Finish = time.Now()
+		return summary
 	}
 
 	sorted := helper.GroupPadsByExpiration(pads, p.expiration)
 
 	var wg sync.WaitGroup
+	var mu sync.Mutex
 
 	for suffix, padIds := range sorted {
 		wg.Add(1)
-		go p.processPads(padIds, suffix, concurrency, &wg)
+		go func(suffix string, padIds []string) {
+			defer wg.Done()
+
+			stats := p.processPads(padIds, suffix, concurrency)
+
+			mu.Lock()
+			summary.Groups[suffix] = stats
+			mu.Unlock()
+		}(suffix, padIds)
 	}
 
 	wg.Wait()
-}
+	summary.Finish = time.Now()
 
-func (p *Purger) processPads(pads []string, suffix string, concurrency int, wg *sync.WaitGroup) {
-	defer wg.Done()
+	return summary
+}
 
+func (p *Purger) processPads(pads []string, suffix string, concurrency int) Stats {
 	log.WithFields(log.Fields{"suffix": suffix, "count": len(pads), "concurrency": concurrency}).Info("start loop")
 	start := time.Now()
 	in := make(chan string)
-	out := make(chan int)
+	out := make(chan Stats)
 
 	for x := 0; x < concurrency; x++ {
 		go p.worker(in, out)
@@ -61,45 +85,104 @@ func (p *Purger) processPads(pads []string, suffix string, concurrency int, wg *
 		}
 		close(in)
 	}()
-	for n := range out {
-		if n == 0 {
-			break
-		}
+
+	var stats Stats
+	for x := 0; x < concurrency; x++ {
+		stats = stats.Add(<-out)
 	}
 
 	elapsed := time.Since(start)
-	log.WithFields(log.Fields{"suffix": suffix, "took": elapsed, "processed": len(pads)}).Info("finished loop")
+	log.WithFields(log.Fields{"suffix": suffix, "took": elapsed, "processed": len(pads), "deleted": stats.Deleted, "failed": stats.Failed}).Info("finished loop")
+
+	return stats
 }
 
-func (p *Purger) worker(pads chan string, out chan int) {
+func (p *Purger) worker(pads chan string, out chan Stats) {
+	var stats Stats
+
 	for pad := range pads {
 		log.WithField("pad", pad).Debug("Process Pad")
+		stats.Inspected++
 
 		revisions, err := p.etherpad.GetRevisionsCount(pad)
 		if err != nil {
 			log.WithError(err).WithField("pad", pad).Error("failed to get last edited time")
+			stats.Failed++
 			continue
 		}
 
 		lastEdited, err := p.etherpad.GetLastEdited(pad)
 		if err != nil {
 			log.WithError(err).Error("")
+			stats.Failed++
 			continue
 		}
 
-		deletable := lastEdited.Before(time.Now().Add(p.expiration.GetDuration(pad))) || revisions == 0
+		deletable := p.expiration.IsExpired(pad, lastEdited, revisions, time.Now())
+		if !deletable {
+			deletable = p.isEmpty(pad, lastEdited)
+		}
 		if !deletable {
+			if !p.maintain(pad, revisions) {
+				stats.Failed++
+			}
 			continue
 		}
 
 		log.WithFields(log.Fields{"pad": pad, "lastEdited": lastEdited, "revisions": revisions}).Info("Delete Pad")
 		if p.dryRun {
+			stats.Deleted++
 			continue
 		}
 		err = p.etherpad.DeletePad(pad)
 		if err != nil {
 			log.WithError(err).WithField("pad", pad).Error("failed to delete pad")
+			stats.Failed++
+			continue
 		}
+		stats.Deleted++
 	}
-	out <- 0
+	out <- stats
+}
+
+// maintain applies the chat retention and the compaction to pads which are not deleted.
+// The compaction is skipped if the pad was already recreated without history by the chat retention.
+// Returns false if the chat retention or the compaction failed.
+func (p *Purger) maintain(pad string, revisions int) bool {
+	if p.ChatRetention != nil {
+		recreated, err := p.ChatRetention.Apply(pad)
+		if err != nil {
+			log.WithError(err).WithField("pad", pad).Error("failed to apply chat retention")
+			return false
+		}
+		if recreated {
+			return true
+		}
+	}
+
+	if p.Compactor != nil && p.Compactor.Applies(pad, revisions) {
+		if err := p.Compactor.Compact(pad); err != nil {
+			log.WithError(err).WithField("pad", pad).Error("failed to compact pad")
+			return false
+		}
+	}
+
+	return true
+}
+
+// isEmpty checks the content of pads which are older than the expiration for empty pads.
+// Returns false if no expiration for empty pads is configured.
+func (p *Purger) isEmpty(pad string, lastEdited time.Time) bool {
+	duration, ok := p.expiration.GetEmptyDuration()
+	if !ok || !lastEdited.Before(time.Now().Add(duration)) {
+		return false
+	}
+
+	text, err := p.etherpad.GetText(pad)
+	if err != nil {
+		log.WithError(err).WithField("pad", pad).Error("failed to get text")
+		return false
+	}
+
+	return helper.IsEmptyText(text, p.DefaultPadText)
 }
@@ -10,16 +10,19 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/chat"
 	"github.com/systemli/etherpad-toolkit/pkg/helper"
 )
 
 var pads = map[string]struct {
 	Revisions  int
 	LastEdited time.Time
+	Text       string
 }{
 	"pad": {
 		Revisions:  30,
 		LastEdited: time.Now().Add(-1 * time.Hour),
+		Text:       "Hello World\n",
 	},
 	"pad+empty": {
 		Revisions:  0,
@@ -29,6 +32,16 @@ var pads = map[string]struct {
 		Revisions:  1,
 		LastEdited: time.Now().Add(-999 * time.Hour),
 	},
+	"pad+whitespace": {
+		Revisions:  4,
+		LastEdited: time.Now().Add(-1 * time.Hour),
+		Text:       " \n\n",
+	},
+	"pad+welcome": {
+		Revisions:  1,
+		LastEdited: time.Now().Add(-1 * time.Hour),
+		Text:       "Welcome to Etherpad!\n",
+	},
 }
 
 var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -116,6 +129,33 @@ var handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if strings.Contains(r.URL.String(), "getText") {
+		padID := r.URL.Query().Get("padID")
+		text := pads[padID].Text
+
+		var body struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+			Data    struct {
+				Text string `json:"text"`
+			} `json:"data"`
+		}
+
+		body.Code = 0
+		body.Message = "ok"
+		body.Data.Text = text
+
+		b, err := json.Marshal(body)
+		if err != nil {
+			w.WriteHeader(500)
+			return
+		}
+
+		w.WriteHeader(200)
+		w.Write(b)
+		return
+	}
+
 	if strings.Contains(r.URL.String(), "deletePad") {
 		padID := r.URL.Query().Get("padID")
 		delete(pads, padID)
@@ -149,11 +189,13 @@ func TestPurger_PurgePads_DryRun(t *testing.T) {
 	}
 	purger := NewPurger(etherpad, expiration, true)
 
-	assert.Equal(t, 3, len(pads))
+	assert.Equal(t, 5, len(pads))
 
-	purger.PurgePads(1)
+	summary := purger.PurgePads(1)
 
-	assert.Equal(t, 3, len(pads))
+	assert.Equal(t, 5, len(pads))
+	assert.True(t, summary.DryRun)
+	assert.Equal(t, Stats{Inspected: 5, Deleted: 2}, summary.Groups[helper.DefaultSuffix])
 }
 
 func TestPurger_PurgePads(t *testing.T) {
@@ -165,9 +207,62 @@ func TestPurger_PurgePads(t *testing.T) {
 	}
 	purger := NewPurger(etherpad, expiration, false)
 
+	assert.Equal(t, 5, len(pads))
+
+	summary := purger.PurgePads(1)
+
+	assert.Equal(t, 3, len(pads))
+	assert.True(t, summary.Successful())
+	assert.Equal(t, Stats{Inspected: 5, Deleted: 2}, summary.Total())
+}
+
+func TestPurger_PurgePads_EmptyPads(t *testing.T) {
+	rec := httptest.NewServer(handler)
+	etherpad := pkg.NewEtherpadClient(rec.URL, "")
+	expiration, err := helper.ParsePadExpiration("default:720h,:empty:30m")
+	if err != nil {
+		t.Fail()
+	}
+	purger := NewPurger(etherpad, expiration, false)
+	purger.DefaultPadText = "Welcome to Etherpad!"
+
 	assert.Equal(t, 3, len(pads))
 
 	purger.PurgePads(1)
 
 	assert.Equal(t, 1, len(pads))
+	assert.Contains(t, pads, "pad")
+}
+
+func TestPurger_PurgePads_ListError(t *testing.T) {
+	etherpad := pkg.NewEtherpadClient("http://127.0.0.1:0", "")
+	expiration, err := helper.ParsePadExpiration("default:720h")
+	if err != nil {
+		t.Fail()
+	}
+	purger := NewPurger(etherpad, expiration, false)
+
+	summary := purger.PurgePads(1)
+
+	assert.NotEmpty(t, summary.Error)
+	assert.False(t, summary.Successful())
+	assert.Empty(t, summary.Groups)
+}
+
+func TestPurger_PurgePads_ChatRetentionError(t *testing.T) {
+	// the handler does not respond to getChatHistory, so the chat retention fails for every kept pad
+	rec := httptest.NewServer(handler)
+	etherpad := pkg.NewEtherpadClient(rec.URL, "")
+	expiration, err := helper.ParsePadExpiration("default:720h")
+	if err != nil {
+		t.Fail()
+	}
+	purger := NewPurger(etherpad, expiration, false)
+	purger.ChatRetention = chat.NewRetention(etherpad, time.Hour, false)
+
+	summary := purger.PurgePads(1)
+
+	assert.Equal(t, Stats{Inspected: len(pads), Failed: len(pads)}, summary.Total())
+	assert.True(t, summary.Completed())
+	assert.False(t, summary.Successful())
 }
@@ -0,0 +1,55 @@
+package purge
+
+import "time"
+
+// Stats contains the counters of a purge run for a group of pads. Failed counts the pads which could not be inspected,
+// deleted, compacted or whose chat retention failed.
+type Stats struct {
+	Inspected int `json:"inspected"`
+	Deleted   int `json:"deleted"`
+	Failed    int `json:"failed"`
+}
+
+// Add sums up the counters of both stats.
+func (s Stats) Add(o Stats) Stats {
+	return Stats{
+		Inspected: s.Inspected + o.Inspected,
+		Deleted:   s.Deleted + o.Deleted,
+		Failed:    s.Failed + o.Failed,
+	}
+}
+
+// Summary describes the result of a purge run.
+type Summary struct {
+	Start  time.Time        `json:"start"`
+	Finish time.Time        `json:"finish"`
+	DryRun bool             `json:"dryRun"`
+	Error  string           `json:"error,omitempty"`
+	Groups map[string]Stats `json:"groups"`
+}
+
+// Duration returns the duration of the purge run.
+func (s Summary) Duration() time.Duration {
+	return s.Finish.Sub(s.Start)
+}
+
+// Total returns the sum of the counters of all groups.
+func (s Summary) Total() Stats {
+	var total Stats
+	for _, stats := range s.Groups {
+		total = total.Add(stats)
+	}
+
+	return total
+}
+
+// Successful returns true if the pads could be listed and no pad failed.
+func (s Summary) Successful() bool {
+	return s.Error == "" && s.Total().Failed == 0
+}
+
+// Completed returns true if the pads could be listed and the run was completed. Failures of single pads are
+// counted in the stats.
+func (s Summary) Completed() bool {
+	return s.Error == ""
+}
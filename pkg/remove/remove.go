@@ -0,0 +1,217 @@
+package remove
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/backup"
+	"github.com/systemli/etherpad-toolkit/pkg/export"
+	"github.com/systemli/etherpad-toolkit/pkg/helper"
+)
+
+const (
+	StatusDeleted = "deleted"
+	StatusDryRun  = "dry-run"
+	StatusMissing = "missing"
+	StatusKept    = "kept"
+	StatusSkipped = "skipped"
+	StatusFailed  = "failed"
+)
+
+// Result is the outcome for a single pad.
+type Result struct {
+	Pad    string `json:"pad"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ReadPads reads one pad ID per line. Empty lines, lines starting with # and duplicates are ignored.
+func ReadPads(r io.Reader) ([]string, error) {
+	var pads []string
+	seen := make(map[string]bool)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		pad := strings.TrimSpace(scanner.Text())
+		if pad == "" || strings.HasPrefix(pad, "#") || seen[pad] {
+			continue
+		}
+		seen[pad] = true
+		pads = append(pads, pad)
+	}
+
+	return pads, scanner.Err()
+}
+
+type Deleter struct {
+	etherpad *pkg.Etherpad
+	dryRun   bool
+
+	// Expiration selects only the pads which are expired by the purge rules. All pads are selected if nil.
+	Expiration helper.PadExpiration
+	// Backup is the path of a tar.gz archive to back up the pads before the deletion. Pads which fail to back up are
+	// not deleted.
+	Backup string
+	// Concurrency is the number of pads which are checked and deleted in parallel.
+	Concurrency int
+}
+
+// NewDeleter returns a instance of Deleter.
+func NewDeleter(ep *pkg.Etherpad, dryRun bool) *Deleter {
+	return &Deleter{
+		etherpad:    ep,
+		dryRun:      dryRun,
+		Concurrency: 4,
+	}
+}
+
+// Select returns the pads to delete. Pads which do not exist or are not expired are returned as results.
+func (d *Deleter) Select(pads []string) ([]string, []Result, error) {
+	all, err := d.etherpad.ListAllPads()
+	if err != nil {
+		return nil, nil, err
+	}
+	existing := make(map[string]bool)
+	for _, pad := range all {
+		existing[pad] = true
+	}
+
+	var candidates []string
+	var results []Result
+	for _, pad := range pads {
+		if existing[pad] {
+			candidates = append(candidates, pad)
+		} else {
+			results = append(results, Result{Pad: pad, Status: StatusMissing})
+		}
+	}
+
+	if d.Expiration == nil {
+		return candidates, results, nil
+	}
+
+	checked := make([]*Result, len(candidates))
+	helper.ForEach(d.Concurrency, len(candidates), func(i int) {
+		checked[i] = d.check(candidates[i])
+	})
+
+	var selected []string
+	for i, result := range checked {
+		if result == nil {
+			selected = append(selected, candidates[i])
+		} else {
+			results = append(results, *result)
+		}
+	}
+
+	return selected, results, nil
+}
+
+// Delete backs up the pads if configured and deletes them. An error is returned if the backup can not be written.
+func (d *Deleter) Delete(pads []string) ([]Result, error) {
+	var results []Result
+
+	if d.Backup != "" && !d.dryRun && len(pads) > 0 {
+		manifest, err := d.backup(pads)
+		if err != nil {
+			return nil, err
+		}
+
+		var saved []string
+		for _, entry := range manifest.Pads {
+			if entry.Error != "" {
+				results = append(results, Result{Pad: entry.Pad, Status: StatusSkipped, Error: "backup failed: " + entry.Error})
+				continue
+			}
+			saved = append(saved, entry.Pad)
+		}
+		pads = saved
+	}
+
+	deleted := make([]Result, len(pads))
+	helper.ForEach(d.Concurrency, len(pads), func(i int) {
+		deleted[i] = d.delete(pads[i])
+	})
+
+	return append(results, deleted...), nil
+}
+
+func (d *Deleter) check(pad string) *Result {
+	revisions, err := d.etherpad.GetRevisionsCount(pad)
+	if err != nil {
+		return &Result{Pad: pad, Status: StatusFailed, Error: err.Error()}
+	}
+	lastEdited, err := d.etherpad.GetLastEdited(pad)
+	if err != nil {
+		return &Result{Pad: pad, Status: StatusFailed, Error: err.Error()}
+	}
+
+	if !d.Expiration.IsExpired(pad, lastEdited, revisions, time.Now()) {
+		return &Result{Pad: pad, Status: StatusKept}
+	}
+
+	return nil
+}
+
+func (d *Deleter) backup(pads []string) (*backup.Manifest, error) {
+	target, err := export.NewArchiveTarget(d.Backup)
+	if err != nil {
+		return nil, err
+	}
+
+	log.WithFields(log.Fields{"count": len(pads), "output": d.Backup}).Info("start backup")
+	manifest, err := backup.NewBackup(d.etherpad).Create(pads, target)
+	if closeErr := target.Close(); err == nil {
+		err = closeErr
+	}
+
+	return manifest, err
+}
+
+func (d *Deleter) delete(pad string) Result {
+	log.WithField("pad", pad).Info("Delete Pad")
+	if d.dryRun {
+		return Result{Pad: pad, Status: StatusDryRun}
+	}
+
+	if err := d.etherpad.DeletePad(pad); err != nil {
+		log.WithError(err).WithField("pad", pad).Error("failed to delete pad")
+		return Result{Pad: pad, Status: StatusFailed, Error: err.Error()}
+	}
+
+	return Result{Pad: pad, Status: StatusDeleted}
+}
+
+// Count returns the number of results with the status.
+func Count(results []Result, status string) int {
+	count := 0
+	for _, result := range results {
+		if result.Status == status {
+			count++
+		}
+	}
+
+	return count
+}
+
+// WriteResults writes the results as table.
+func WriteResults(w io.Writer, results []Result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "PAD\tSTATUS\tERROR"); err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\n", result.Pad, result.Status, result.Error); err != nil {
+			return err
+		}
+	}
+
+	return tw.Flush()
+}
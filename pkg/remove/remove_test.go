@@ -0,0 +1,97 @@
+package remove
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/etherpadtest"
+	"github.com/systemli/etherpad-toolkit/pkg/helper"
+)
+
+func newFake() *etherpadtest.Fake {
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+
+	return &etherpadtest.Fake{
+		Responses: map[string]etherpadtest.Response{
+			"listAllPads":       etherpadtest.Data(`{"padIDs": ["old", "new", "broken"]}`),
+			"getRevisionsCount": etherpadtest.Data(`{"revisions": 3}`),
+			"getLastEdited": func(r *http.Request) string {
+				lastEdited := int64(1000)
+				if r.FormValue("padID") == "new" {
+					lastEdited = now
+				}
+				return fmt.Sprintf(`{"lastEdited": %d}`, lastEdited)
+			},
+		},
+		Export: func(pad string) string {
+			if pad == "broken" {
+				return ""
+			}
+			return `{"pad:` + pad + `":{}}`
+		},
+	}
+}
+
+func TestReadPads(t *testing.T) {
+	pads, err := ReadPads(strings.NewReader("pad1\n\n# comment\n  pad2 \npad1\n"))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"pad1", "pad2"}, pads)
+}
+
+func TestDeleter_Select(t *testing.T) {
+	ts := etherpadtest.NewServer(newFake())
+	defer ts.Close()
+
+	deleter := NewDeleter(pkg.NewEtherpadClient(ts.URL, ""), false)
+	selected, results, err := deleter.Select([]string{"old", "new", "gone"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"old", "new"}, selected)
+	assert.Equal(t, []Result{{Pad: "gone", Status: StatusMissing}}, results)
+
+	deleter.Expiration = helper.PadExpiration{helper.DefaultSuffix: time.Hour}
+	selected, results, err = deleter.Select([]string{"old", "new", "gone"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"old"}, selected)
+	assert.Equal(t, []Result{{Pad: "gone", Status: StatusMissing}, {Pad: "new", Status: StatusKept}}, results)
+}
+
+func TestDeleter_Delete(t *testing.T) {
+	fake := newFake()
+	ts := etherpadtest.NewServer(fake)
+	defer ts.Close()
+
+	deleter := NewDeleter(pkg.NewEtherpadClient(ts.URL, ""), true)
+	results, err := deleter.Delete([]string{"old", "new"})
+	assert.Nil(t, err)
+	assert.Equal(t, []Result{{Pad: "old", Status: StatusDryRun}, {Pad: "new", Status: StatusDryRun}}, results)
+	assert.Empty(t, fake.Calls("deletePad"))
+
+	deleter = NewDeleter(pkg.NewEtherpadClient(ts.URL, ""), false)
+	deleter.Backup = filepath.Join(t.TempDir(), "backup.tar.gz")
+	results, err = deleter.Delete([]string{"old", "broken"})
+	assert.Nil(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, "broken", results[0].Pad)
+	assert.Equal(t, StatusSkipped, results[0].Status)
+	assert.Contains(t, results[0].Error, "backup failed")
+	assert.Equal(t, Result{Pad: "old", Status: StatusDeleted}, results[1])
+	assert.Equal(t, []string{"deletePad old"}, fake.Calls("deletePad"))
+	assert.FileExists(t, deleter.Backup)
+
+	assert.Equal(t, 1, Count(results, StatusDeleted))
+}
+
+func TestWriteResults(t *testing.T) {
+	var b bytes.Buffer
+	err := WriteResults(&b, []Result{{Pad: "pad1", Status: StatusDeleted}, {Pad: "pad2", Status: StatusFailed, Error: "boom"}})
+	assert.Nil(t, err)
+
+	assert.Equal(t, "PAD   STATUS   ERROR\npad1  deleted  \npad2  failed   boom\n", b.String())
+}
@@ -0,0 +1,228 @@
+package rename
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/helper"
+)
+
+// Rename moves the source pad to the destination.
+type Rename struct {
+	Source      string
+	Destination string
+}
+
+// FromPattern returns the renames for all pads which match the pattern. The destination is the pad with the matches
+// replaced by the template, as in regexp.ReplaceAllString. Pads which do not change are skipped.
+func FromPattern(pads []string, pattern *regexp.Regexp, template string) []Rename {
+	var renames []Rename
+	for _, pad := range pads {
+		if !pattern.MatchString(pad) {
+			continue
+		}
+		destination := pattern.ReplaceAllString(pad, template)
+		if destination != pad {
+			renames = append(renames, Rename{Source: pad, Destination: destination})
+		}
+	}
+
+	return renames
+}
+
+// LoadMapping reads the renames from a CSV file with the columns source and destination. Lines starting with # are
+// ignored.
+func LoadMapping(path string) ([]Rename, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	renames := make([]Rename, 0, len(records))
+	for _, record := range records {
+		renames = append(renames, Rename{Source: record[0], Destination: record[1]})
+	}
+
+	return renames, nil
+}
+
+// Plan validates the renames against the existing pads and returns them in batches. The renames of a batch are
+// independent and can be executed in parallel, a rename to a pad which is renamed itself is executed in a later batch.
+// An error is returned for missing sources, duplicate sources or destinations, cycles and existing destinations
+// without force.
+func Plan(renames []Rename, existing []string, force bool) ([][]Rename, error) {
+	exists := make(map[string]bool)
+	for _, pad := range existing {
+		exists[pad] = true
+	}
+
+	sources := make(map[string]Rename)
+	destinations := make(map[string]string)
+	var errs []string
+	for _, r := range renames {
+		if r.Source == "" || r.Destination == "" || r.Source == r.Destination {
+			errs = append(errs, fmt.Sprintf("invalid rename: %q -> %q", r.Source, r.Destination))
+			continue
+		}
+		if !exists[r.Source] {
+			errs = append(errs, fmt.Sprintf("pad does not exist: %s", r.Source))
+		}
+		if _, ok := sources[r.Source]; ok {
+			errs = append(errs, fmt.Sprintf("pad is renamed twice: %s", r.Source))
+		}
+		if other, ok := destinations[r.Destination]; ok {
+			errs = append(errs, fmt.Sprintf("collision: %s and %s are renamed to %s", other, r.Source, r.Destination))
+		}
+		sources[r.Source] = r
+		destinations[r.Destination] = r.Source
+	}
+
+	for _, r := range renames {
+		if _, moved := sources[r.Destination]; exists[r.Destination] && !moved && !force {
+			errs = append(errs, fmt.Sprintf("destination exists: %s", r.Destination))
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid renames:\n%s", strings.Join(errs, "\n"))
+	}
+
+	var batches [][]Rename
+	pending := renames
+	for len(pending) > 0 {
+		blocked := make(map[string]bool)
+		for _, r := range pending {
+			blocked[r.Source] = true
+		}
+
+		var batch, rest []Rename
+		for _, r := range pending {
+			if blocked[r.Destination] {
+				rest = append(rest, r)
+			} else {
+				batch = append(batch, r)
+			}
+		}
+
+		if len(batch) == 0 {
+			var cycle []string
+			for _, r := range rest {
+				cycle = append(cycle, fmt.Sprintf("%s -> %s", r.Source, r.Destination))
+			}
+			sort.Strings(cycle)
+			return nil, fmt.Errorf("cycle detected: %s", strings.Join(cycle, ", "))
+		}
+
+		batches = append(batches, batch)
+		pending = rest
+	}
+
+	return batches, nil
+}
+
+// WritePreview writes the renames as table. Renames which overwrite an existing pad are marked.
+func WritePreview(w io.Writer, batches [][]Rename, existing []string) error {
+	exists := make(map[string]bool)
+	for _, pad := range existing {
+		exists[pad] = true
+	}
+	sources := make(map[string]bool)
+	for _, batch := range batches {
+		for _, r := range batch {
+			sources[r.Source] = true
+		}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "SOURCE\tDESTINATION\tNOTE"); err != nil {
+		return err
+	}
+	for _, batch := range batches {
+		for _, r := range batch {
+			note := ""
+			if exists[r.Destination] && !sources[r.Destination] {
+				note = "overwrite"
+			}
+			if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\n", r.Source, r.Destination, note); err != nil {
+				return err
+			}
+		}
+	}
+
+	return tw.Flush()
+}
+
+type Mover struct {
+	etherpad *pkg.Etherpad
+	force    bool
+	dryRun   bool
+
+	// Concurrency is the number of pads which are moved in parallel.
+	Concurrency int
+}
+
+// NewMover returns a instance of Mover. If force is true and the destination pad exists, it will be overwritten.
+func NewMover(ep *pkg.Etherpad, force, dryRun bool) *Mover {
+	return &Mover{
+		etherpad:    ep,
+		force:       force,
+		dryRun:      dryRun,
+		Concurrency: 4,
+	}
+}
+
+// Execute moves the pads batch by batch and returns the number of failed renames. A rename is skipped if its
+// destination could not be moved away before.
+func (m *Mover) Execute(batches [][]Rename) int {
+	var mu sync.Mutex
+	failed := make(map[string]bool)
+	for _, batch := range batches {
+		helper.ForEach(m.Concurrency, len(batch), func(i int) {
+			r := batch[i]
+			if err := m.move(r, failed, &mu); err != nil {
+				log.WithError(err).WithFields(log.Fields{"source": r.Source, "destination": r.Destination}).Error("failed to move pad")
+				mu.Lock()
+				failed[r.Source] = true
+				mu.Unlock()
+			}
+		})
+	}
+
+	return len(failed)
+}
+
+func (m *Mover) move(r Rename, failed map[string]bool, mu *sync.Mutex) error {
+	mu.Lock()
+	blocked := failed[r.Destination]
+	mu.Unlock()
+	if blocked {
+		return fmt.Errorf("destination %s was not moved", r.Destination)
+	}
+
+	log.WithFields(log.Fields{"source": r.Source, "destination": r.Destination}).Info("Move Pad")
+	if m.dryRun {
+		return nil
+	}
+
+	return m.etherpad.MovePad(r.Source, r.Destination, m.force)
+}
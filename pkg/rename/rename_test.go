@@ -0,0 +1,92 @@
+package rename
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/systemli/etherpad-toolkit/pkg"
+)
+
+func TestFromPattern(t *testing.T) {
+	pads := []string{"pad1-tmp", "pad2-tmp", "pad3-keep", "tmp"}
+
+	renames := FromPattern(pads, regexp.MustCompile(`^(.+)-tmp$`), "${1}-temp")
+	assert.Equal(t, []Rename{{Source: "pad1-tmp", Destination: "pad1-temp"}, {Source: "pad2-tmp", Destination: "pad2-temp"}}, renames)
+
+	renames = FromPattern([]string{"pad1"}, regexp.MustCompile(`^`), "g.abc$$")
+	assert.Equal(t, []Rename{{Source: "pad1", Destination: "g.abc$pad1"}}, renames)
+}
+
+func TestLoadMapping(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mapping.csv")
+	assert.Nil(t, os.WriteFile(path, []byte("# source,destination\npad1,pad2\npad3, pad4\n"), 0o600))
+
+	renames, err := LoadMapping(path)
+	assert.Nil(t, err)
+	assert.Equal(t, []Rename{{Source: "pad1", Destination: "pad2"}, {Source: "pad3", Destination: "pad4"}}, renames)
+}
+
+func TestPlan(t *testing.T) {
+	existing := []string{"a", "b", "c", "x"}
+
+	// b -> c has to be moved before a -> b, c exists and is overwritten with force
+	batches, err := Plan([]Rename{{Source: "a", Destination: "b"}, {Source: "b", Destination: "c"}}, existing, true)
+	assert.Nil(t, err)
+	assert.Equal(t, [][]Rename{{{Source: "b", Destination: "c"}}, {{Source: "a", Destination: "b"}}}, batches)
+
+	_, err = Plan([]Rename{{Source: "a", Destination: "b"}, {Source: "b", Destination: "c"}}, existing, false)
+	assert.EqualError(t, err, "invalid renames:\ndestination exists: c")
+
+	_, err = Plan([]Rename{{Source: "a", Destination: "b"}, {Source: "b", Destination: "a"}}, existing, false)
+	assert.EqualError(t, err, "cycle detected: a -> b, b -> a")
+
+	_, err = Plan([]Rename{{Source: "a", Destination: "y"}, {Source: "x", Destination: "y"}, {Source: "z", Destination: "w"}}, existing, false)
+	assert.EqualError(t, err, "invalid renames:\ncollision: a and x are renamed to y\npad does not exist: z")
+
+	_, err = Plan([]Rename{{Source: "a", Destination: "y"}, {Source: "a", Destination: "w"}}, existing, false)
+	assert.EqualError(t, err, "invalid renames:\npad is renamed twice: a")
+}
+
+func TestWritePreview(t *testing.T) {
+	var b bytes.Buffer
+	err := WritePreview(&b, [][]Rename{{{Source: "b", Destination: "c"}}, {{Source: "a", Destination: "b"}}}, []string{"a", "b", "c"})
+	assert.Nil(t, err)
+	assert.Equal(t, "SOURCE  DESTINATION  NOTE\nb       c            overwrite\na       b            \n", b.String())
+}
+
+func TestMover_Execute(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls = append(calls, r.FormValue("sourceID")+" -> "+r.FormValue("destinationID"))
+		mu.Unlock()
+
+		if r.FormValue("sourceID") == "b" {
+			_, _ = w.Write([]byte(`{"code": 1, "message":"destinationID does already exist", "data": null}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": null}`))
+	}))
+	defer ts.Close()
+
+	batches := [][]Rename{{{Source: "b", Destination: "c"}, {Source: "d", Destination: "e"}}, {{Source: "a", Destination: "b"}}}
+
+	failed := NewMover(pkg.NewEtherpadClient(ts.URL, ""), false, false).Execute(batches)
+	assert.Equal(t, 2, failed)
+	assert.Len(t, calls, 2)
+	assert.NotContains(t, strings.Join(calls, ","), "a -> b")
+
+	calls = nil
+	failed = NewMover(pkg.NewEtherpadClient(ts.URL, ""), false, true).Execute(batches)
+	assert.Equal(t, 0, failed)
+	assert.Empty(t, calls)
+}
@@ -0,0 +1,158 @@
+package revert
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/helper"
+	"github.com/systemli/etherpad-toolkit/pkg/history"
+)
+
+// Revert restores a pad to the last revision before the first edit of the author.
+type Revert struct {
+	Pad string
+	// Revision is the revision which is restored.
+	Revision int
+	// FirstEdit is the time of the first edit of the author.
+	FirstEdit time.Time
+	// Discarded is the number of revisions which are discarded, including edits of other authors.
+	Discarded int
+}
+
+type Reverter struct {
+	etherpad *pkg.Etherpad
+	dryRun   bool
+
+	// Concurrency is the number of pads which are checked and restored in parallel.
+	Concurrency int
+}
+
+// NewReverter returns a instance of Reverter.
+func NewReverter(ep *pkg.Etherpad, dryRun bool) *Reverter {
+	return &Reverter{
+		etherpad:    ep,
+		dryRun:      dryRun,
+		Concurrency: 4,
+	}
+}
+
+// Plan returns the reverts for the pads which were edited by the author after the time. Pads which were created by
+// the author can not be reverted and are skipped with a warning.
+func (r *Reverter) Plan(pads []string, author string, after time.Time) []Revert {
+	var mu sync.Mutex
+	var reverts []Revert
+	helper.ForEach(r.Concurrency, len(pads), func(i int) {
+		revert, err := r.plan(pads[i], author, after)
+		if err != nil {
+			log.WithError(err).WithField("pad", pads[i]).Warn("failed to check pad")
+			return
+		}
+		if revert == nil {
+			return
+		}
+
+		mu.Lock()
+		reverts = append(reverts, *revert)
+		mu.Unlock()
+	})
+
+	sort.Slice(reverts, func(i, j int) bool { return reverts[i].Pad < reverts[j].Pad })
+
+	return reverts
+}
+
+// Apply restores the pads and returns the number of failed reverts.
+func (r *Reverter) Apply(reverts []Revert) int {
+	var mu sync.Mutex
+	failed := 0
+	helper.ForEach(r.Concurrency, len(reverts), func(i int) {
+		revert := reverts[i]
+		log.WithFields(log.Fields{"pad": revert.Pad, "revision": revert.Revision}).Info("Restore Revision")
+		if r.dryRun {
+			return
+		}
+
+		if err := r.etherpad.RestoreRevision(revert.Pad, revert.Revision); err != nil {
+			log.WithError(err).WithField("pad", revert.Pad).Error("failed to restore revision")
+			mu.Lock()
+			failed++
+			mu.Unlock()
+		}
+	})
+
+	return failed
+}
+
+func (r *Reverter) plan(pad, author string, after time.Time) (*Revert, error) {
+	// check the authors and the last edit before the history is downloaded
+	authors, err := r.etherpad.ListAuthorsOfPad(pad)
+	if err != nil {
+		return nil, err
+	}
+	if !contains(authors, author) {
+		return nil, nil
+	}
+	lastEdited, err := r.etherpad.GetLastEdited(pad)
+	if err != nil {
+		return nil, err
+	}
+	if lastEdited.Before(after) {
+		return nil, nil
+	}
+
+	revisions, err := history.Load(r.etherpad, pad)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, rev := range revisions {
+		if rev.Author != author || rev.Timestamp.Before(after) {
+			continue
+		}
+		if rev.Number == 0 {
+			return nil, errors.New("pad was created by the author")
+		}
+
+		return &Revert{
+			Pad:       pad,
+			Revision:  rev.Number - 1,
+			FirstEdit: rev.Timestamp,
+			Discarded: len(revisions) - i,
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// WriteReverts writes the reverts as table, e.g. for a dry-run.
+func WriteReverts(w io.Writer, reverts []Revert) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "PAD\tREVISION\tFIRST EDIT\tDISCARDED"); err != nil {
+		return err
+	}
+
+	for _, revert := range reverts {
+		if _, err := fmt.Fprintf(tw, "%s\t%d\t%s\t%d\n", revert.Pad, revert.Revision, revert.FirstEdit.Format(time.RFC3339), revert.Discarded); err != nil {
+			return err
+		}
+	}
+
+	return tw.Flush()
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
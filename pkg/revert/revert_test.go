@@ -0,0 +1,75 @@
+package revert
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/etherpadtest"
+)
+
+var exports = map[string]string{
+	"vandalized": `{
+		"pad:vandalized:revs:0": {"changeset": "Z:1>1+1$a", "meta": {"author": "", "timestamp": 1609556400000}},
+		"pad:vandalized:revs:1": {"changeset": "Z:2>1+1$b", "meta": {"author": "a.alice", "timestamp": 1609556500000}},
+		"pad:vandalized:revs:2": {"changeset": "Z:3>1+1$c", "meta": {"author": "a.vandal", "timestamp": 1609556700000}},
+		"pad:vandalized:revs:3": {"changeset": "Z:4>1+1$d", "meta": {"author": "a.alice", "timestamp": 1609556800000}}
+	}`,
+	"created": `{
+		"pad:created:revs:0": {"changeset": "Z:1>1+1$a", "meta": {"author": "a.vandal", "timestamp": 1609556700000}}
+	}`,
+	"earlier": `{
+		"pad:earlier:revs:0": {"changeset": "Z:1>1+1$a", "meta": {"author": "", "timestamp": 1609556400000}},
+		"pad:earlier:revs:1": {"changeset": "Z:2>1+1$b", "meta": {"author": "a.vandal", "timestamp": 1609556500000}},
+		"pad:earlier:revs:2": {"changeset": "Z:3>1+1$c", "meta": {"author": "a.alice", "timestamp": 1609556700000}}
+	}`,
+}
+
+func newFake() *etherpadtest.Fake {
+	return &etherpadtest.Fake{
+		Responses: map[string]etherpadtest.Response{
+			"listAuthorsOfPad": func(r *http.Request) string {
+				if r.FormValue("padID") == "clean" {
+					return `{"authorIDs": ["a.alice"]}`
+				}
+				return `{"authorIDs": ["a.alice", "a.vandal"]}`
+			},
+			"getLastEdited": etherpadtest.Data(`{"lastEdited": 1609556800000}`),
+		},
+		Export: func(pad string) string {
+			return exports[pad]
+		},
+	}
+}
+
+func TestReverter(t *testing.T) {
+	fake := newFake()
+	ts := etherpadtest.NewServer(fake)
+	defer ts.Close()
+
+	after := time.Unix(1609556600, 0)
+	reverter := NewReverter(pkg.NewEtherpadClient(ts.URL, ""), false)
+
+	reverts := reverter.Plan([]string{"vandalized", "created", "earlier", "clean"}, "a.vandal", after)
+	assert.Equal(t, []Revert{{Pad: "vandalized", Revision: 1, FirstEdit: time.Unix(1609556700, 0), Discarded: 2}}, reverts)
+
+	// the pad was not edited after the time
+	assert.Empty(t, reverter.Plan([]string{"vandalized"}, "a.vandal", time.Unix(1609556900, 0)))
+
+	assert.Equal(t, 0, reverter.Apply(reverts))
+	assert.Equal(t, []string{"restoreRevision vandalized 1"}, fake.Calls("restoreRevision"))
+
+	fake.Reset()
+	assert.Equal(t, 0, NewReverter(pkg.NewEtherpadClient(ts.URL, ""), true).Apply(reverts))
+	assert.Empty(t, fake.Calls("restoreRevision"))
+}
+
+func TestWriteReverts(t *testing.T) {
+	var b bytes.Buffer
+	err := WriteReverts(&b, []Revert{{Pad: "pad1", Revision: 41, FirstEdit: time.Unix(1609556645, 0).UTC(), Discarded: 3}})
+	assert.Nil(t, err)
+	assert.Equal(t, "PAD   REVISION  FIRST EDIT            DISCARDED\npad1  41        2021-01-02T03:04:05Z  3\n", b.String())
+}
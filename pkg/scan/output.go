@@ -0,0 +1,50 @@
+package scan
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+)
+
+// Write writes the ranked findings in the format.
+func Write(w io.Writer, format string, findings []Finding) error {
+	switch format {
+	case FormatTable:
+		return writeTable(w, findings)
+	case FormatJSON:
+		if findings == nil {
+			findings = []Finding{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(findings)
+	}
+
+	return fmt.Errorf("unknown format: %s", format)
+}
+
+func writeTable(w io.Writer, findings []Finding) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "SCORE\tFLAGGED\tPAD\tREASONS"); err != nil {
+		return err
+	}
+
+	for _, finding := range findings {
+		flagged := "no"
+		if finding.Flagged {
+			flagged = "yes"
+		}
+		if _, err := fmt.Fprintf(tw, "%g\t%s\t%s\t%s\n", finding.Score, flagged, finding.Pad, strings.Join(finding.Reasons, "; ")); err != nil {
+			return err
+		}
+	}
+
+	return tw.Flush()
+}
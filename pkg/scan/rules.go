@@ -0,0 +1,74 @@
+package scan
+
+import (
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Rules configure the heuristics of the scan. A heuristic is disabled if its score is 0.
+type Rules struct {
+	// Threshold is the score from which a pad is flagged.
+	Threshold float64 `yaml:"threshold"`
+
+	Links    LinkRule     `yaml:"links"`
+	Domains  ListRule     `yaml:"domains"`
+	Keywords ListRule     `yaml:"keywords"`
+	Repeated RepeatedRule `yaml:"repeated"`
+	Burst    BurstRule    `yaml:"burst"`
+}
+
+// LinkRule scores pads with many links relative to the number of words.
+type LinkRule struct {
+	Min     int     `yaml:"min"`
+	Density float64 `yaml:"density"`
+	Score   float64 `yaml:"score"`
+}
+
+// ListRule scores every entry of the list which is found in a pad.
+type ListRule struct {
+	List  []string `yaml:"list"`
+	Score float64  `yaml:"score"`
+}
+
+// RepeatedRule scores pads whose text is shared by at least MinPads pads.
+type RepeatedRule struct {
+	MinPads   int     `yaml:"min_pads"`
+	MinLength int     `yaml:"min_length"`
+	Score     float64 `yaml:"score"`
+}
+
+// BurstRule scores pads of a single author if the author edited at least MinPads pads within the window. Etherpad has
+// no creation time of pads, so the time of the last edit is used.
+type BurstRule struct {
+	MinPads int           `yaml:"min_pads"`
+	Window  time.Duration `yaml:"window"`
+	Score   float64       `yaml:"score"`
+}
+
+// DefaultRules returns the rules which are used if no rules file is given.
+func DefaultRules() Rules {
+	return Rules{
+		Threshold: 5,
+		Links:     LinkRule{Min: 5, Density: 0.1, Score: 3},
+		Domains:   ListRule{Score: 5},
+		Keywords:  ListRule{Score: 2},
+		Repeated:  RepeatedRule{MinPads: 3, MinLength: 50, Score: 3},
+		Burst:     BurstRule{MinPads: 5, Window: time.Hour, Score: 2},
+	}
+}
+
+// LoadRules reads the rules from a YAML file. Missing values are taken from the default rules.
+func LoadRules(path string) (Rules, error) {
+	rules := DefaultRules()
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return rules, err
+	}
+
+	err = yaml.Unmarshal(b, &rules)
+
+	return rules, err
+}
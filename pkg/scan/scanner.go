@@ -0,0 +1,254 @@
+package scan
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/helper"
+)
+
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"']+`)
+
+// Finding is the score of a pad with the reasons which contributed to it.
+type Finding struct {
+	Pad     string   `json:"pad"`
+	Score   float64  `json:"score"`
+	Flagged bool     `json:"flagged"`
+	Reasons []string `json:"reasons"`
+}
+
+type padData struct {
+	pad        string
+	text       string
+	authors    []string
+	lastEdited time.Time
+}
+
+type Scanner struct {
+	etherpad *pkg.Etherpad
+	rules    Rules
+
+	// DefaultPadText is the text Etherpad puts into new pads. Pads which contain only this text are treated as empty.
+	DefaultPadText string
+	// Concurrency is the number of pads which are fetched in parallel.
+	Concurrency int
+}
+
+// NewScanner returns a instance of Scanner.
+func NewScanner(ep *pkg.Etherpad, rules Rules) *Scanner {
+	return &Scanner{
+		etherpad:    ep,
+		rules:       rules,
+		Concurrency: 4,
+	}
+}
+
+// Scan fetches the pads and returns the findings with a score above 0, ranked by score. Pads which can not be fetched
+// are skipped.
+func (s *Scanner) Scan(pads []string) []Finding {
+	data := s.fetch(pads)
+
+	findings := make(map[string]*Finding)
+	add := func(pad string, score float64, reason string) {
+		finding, ok := findings[pad]
+		if !ok {
+			finding = &Finding{Pad: pad}
+			findings[pad] = finding
+		}
+		finding.Score += score
+		finding.Reasons = append(finding.Reasons, reason)
+	}
+
+	for _, d := range data {
+		s.scoreContent(d, add)
+	}
+	s.scoreRepeated(data, add)
+	s.scoreBurst(data, add)
+
+	result := make([]Finding, 0, len(findings))
+	for _, finding := range findings {
+		finding.Flagged = finding.Score >= s.rules.Threshold
+		result = append(result, *finding)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		return result[i].Pad < result[j].Pad
+	})
+
+	return result
+}
+
+// Flagged returns the pads of the flagged findings.
+func Flagged(findings []Finding) []string {
+	var pads []string
+	for _, finding := range findings {
+		if finding.Flagged {
+			pads = append(pads, finding.Pad)
+		}
+	}
+
+	return pads
+}
+
+func (s *Scanner) fetch(pads []string) []padData {
+	data := make([]*padData, len(pads))
+	helper.ForEach(s.Concurrency, len(pads), func(i int) {
+		d, err := s.fetchPad(pads[i])
+		if err != nil {
+			log.WithError(err).WithField("pad", pads[i]).Warn("failed to fetch pad")
+			return
+		}
+		data[i] = d
+	})
+
+	var result []padData
+	for _, d := range data {
+		if d != nil {
+			result = append(result, *d)
+		}
+	}
+
+	return result
+}
+
+func (s *Scanner) fetchPad(pad string) (*padData, error) {
+	var err error
+	d := &padData{pad: pad}
+
+	if d.text, err = s.etherpad.GetText(pad); err != nil {
+		return nil, err
+	}
+	if s.rules.Burst.Score > 0 {
+		if d.authors, err = s.etherpad.ListAuthorsOfPad(pad); err != nil {
+			return nil, err
+		}
+		if d.lastEdited, err = s.etherpad.GetLastEdited(pad); err != nil {
+			return nil, err
+		}
+	}
+
+	return d, nil
+}
+
+func (s *Scanner) scoreContent(d padData, add func(pad string, score float64, reason string)) {
+	if helper.IsEmptyText(d.text, s.DefaultPadText) {
+		return
+	}
+
+	links := linkPattern.FindAllString(d.text, -1)
+	words := len(strings.Fields(d.text))
+	if rule := s.rules.Links; rule.Score > 0 && len(links) >= rule.Min {
+		if density := float64(len(links)) / float64(words); density >= rule.Density {
+			add(d.pad, rule.Score, fmt.Sprintf("links: %d (%.2f per word)", len(links), density))
+		}
+	}
+
+	if rule := s.rules.Domains; rule.Score > 0 {
+		hosts := make(map[string]bool)
+		for _, link := range links {
+			hosts[host(link)] = true
+		}
+		for _, domain := range rule.List {
+			domain = strings.ToLower(domain)
+			for h := range hosts {
+				if h == domain || strings.HasSuffix(h, "."+domain) {
+					add(d.pad, rule.Score, "domain: "+domain)
+					break
+				}
+			}
+		}
+	}
+
+	if rule := s.rules.Keywords; rule.Score > 0 {
+		text := strings.ToLower(d.text)
+		for _, keyword := range rule.List {
+			if strings.Contains(text, strings.ToLower(keyword)) {
+				add(d.pad, rule.Score, "keyword: "+keyword)
+			}
+		}
+	}
+}
+
+func (s *Scanner) scoreRepeated(data []padData, add func(pad string, score float64, reason string)) {
+	rule := s.rules.Repeated
+	if rule.Score <= 0 || rule.MinPads < 2 {
+		return
+	}
+
+	groups := make(map[string][]string)
+	for _, d := range data {
+		if helper.IsEmptyText(d.text, s.DefaultPadText) {
+			continue
+		}
+		normalized := strings.ToLower(strings.Join(strings.Fields(d.text), " "))
+		if len(normalized) < rule.MinLength {
+			continue
+		}
+		groups[normalized] = append(groups[normalized], d.pad)
+	}
+
+	for _, pads := range groups {
+		if len(pads) < rule.MinPads {
+			continue
+		}
+		for _, pad := range pads {
+			add(pad, rule.Score, fmt.Sprintf("repeated content in %d pads", len(pads)))
+		}
+	}
+}
+
+func (s *Scanner) scoreBurst(data []padData, add func(pad string, score float64, reason string)) {
+	rule := s.rules.Burst
+	if rule.Score <= 0 || rule.MinPads < 1 {
+		return
+	}
+
+	byAuthor := make(map[string][]padData)
+	for _, d := range data {
+		if len(d.authors) == 1 {
+			byAuthor[d.authors[0]] = append(byAuthor[d.authors[0]], d)
+		}
+	}
+
+	for author, pads := range byAuthor {
+		sort.Slice(pads, func(i, j int) bool { return pads[i].lastEdited.Before(pads[j].lastEdited) })
+
+		burst := make([]bool, len(pads))
+		for i, j := 0, 0; i < len(pads); i++ {
+			for j < len(pads) && pads[j].lastEdited.Sub(pads[i].lastEdited) <= rule.Window {
+				j++
+			}
+			if j-i >= rule.MinPads {
+				for k := i; k < j; k++ {
+					burst[k] = true
+				}
+			}
+		}
+
+		for i, d := range pads {
+			if burst[i] {
+				add(d.pad, rule.Score, fmt.Sprintf("burst: at least %d pads by %s within %s", rule.MinPads, author, rule.Window))
+			}
+		}
+	}
+}
+
+// host returns the lower case host of the link without www.
+func host(link string) string {
+	h := strings.ToLower(link)
+	if i := strings.Index(h, "://"); i >= 0 {
+		h = h[i+3:]
+	}
+	if i := strings.IndexAny(h, "/?#:"); i >= 0 {
+		h = h[:i]
+	}
+
+	return strings.TrimPrefix(h, "www.")
+}
@@ -0,0 +1,127 @@
+package scan
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/systemli/etherpad-toolkit/pkg"
+)
+
+const repeated = "Buy cheap watches online, best prices guaranteed, visit our shop today!"
+
+var texts = map[string]string{
+	"links":    "http://a.example http://b.example http://c.example www.d.example https://e.example see",
+	"domain":   "Hello, please visit https://www.spam.example/offer",
+	"keyword":  "Best CASINO in town",
+	"repeat1":  repeated,
+	"repeat2":  repeated,
+	"repeat3":  "  buy cheap watches online, best prices guaranteed,\nvisit our shop today!",
+	"default":  "Welcome to Etherpad!",
+	"harmless": "Meeting notes",
+}
+
+func server(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pad := r.FormValue("padID")
+		parts := strings.Split(r.URL.Path, "/")
+		switch parts[len(parts)-1] {
+		case "getText":
+			text, ok := texts[pad]
+			if !ok {
+				_, _ = w.Write([]byte(`{"code": 1, "message":"padID does not exist", "data": null}`))
+				return
+			}
+			b, _ := json.Marshal(map[string]interface{}{"code": 0, "message": "ok", "data": map[string]string{"text": text}})
+			_, _ = w.Write(b)
+		case "listAuthorsOfPad":
+			author := "a.spammer"
+			if pad == "harmless" || pad == "default" {
+				author = "a.user"
+			}
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"authorIDs": ["` + author + `"]}}`))
+		case "getLastEdited":
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"code": 0, "message":"ok", "data": {"lastEdited": %d}}`, 1609556645000+int64(len(pad))*60000)))
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+}
+
+func TestScanner_Scan(t *testing.T) {
+	ts := server(t)
+	defer ts.Close()
+
+	rules := DefaultRules()
+	rules.Domains.List = []string{"spam.example"}
+	rules.Keywords.List = []string{"casino"}
+	rules.Burst.MinPads = 0
+
+	scanner := NewScanner(pkg.NewEtherpadClient(ts.URL, ""), rules)
+	scanner.DefaultPadText = "Welcome to Etherpad!"
+
+	pads := []string{"links", "domain", "keyword", "repeat1", "repeat2", "repeat3", "default", "harmless", "missing"}
+	findings := scanner.Scan(pads)
+
+	assert.Equal(t, []Finding{
+		{Pad: "domain", Score: 5, Flagged: true, Reasons: []string{"domain: spam.example"}},
+		{Pad: "links", Score: 3, Reasons: []string{"links: 5 (0.83 per word)"}},
+		{Pad: "repeat1", Score: 3, Reasons: []string{"repeated content in 3 pads"}},
+		{Pad: "repeat2", Score: 3, Reasons: []string{"repeated content in 3 pads"}},
+		{Pad: "repeat3", Score: 3, Reasons: []string{"repeated content in 3 pads"}},
+		{Pad: "keyword", Score: 2, Reasons: []string{"keyword: casino"}},
+	}, findings)
+	assert.Equal(t, []string{"domain"}, Flagged(findings))
+}
+
+func TestScanner_Scan_Burst(t *testing.T) {
+	ts := server(t)
+	defer ts.Close()
+
+	rules := Rules{Threshold: 1, Burst: BurstRule{MinPads: 3, Window: 2 * time.Minute, Score: 1}}
+	scanner := NewScanner(pkg.NewEtherpadClient(ts.URL, ""), rules)
+
+	// the pads of a.spammer are edited 5, 6, 7 and 7 minutes after the base time
+	findings := scanner.Scan([]string{"links", "domain", "keyword", "repeat1", "harmless"})
+	assert.Equal(t, []string{"domain", "keyword", "links", "repeat1"}, Flagged(findings))
+	assert.Equal(t, "burst: at least 3 pads by a.spammer within 2m0s", findings[0].Reasons[0])
+
+	rules.Burst.Window = time.Second
+	findings = NewScanner(pkg.NewEtherpadClient(ts.URL, ""), rules).Scan([]string{"links", "domain", "keyword", "repeat1", "harmless"})
+	assert.Empty(t, findings)
+}
+
+func TestLoadRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	assert.Nil(t, os.WriteFile(path, []byte("threshold: 4\ndomains:\n  list: [spam.example]\nburst:\n  window: 30m\n"), 0o600))
+
+	rules, err := LoadRules(path)
+	assert.Nil(t, err)
+	assert.Equal(t, 4.0, rules.Threshold)
+	assert.Equal(t, []string{"spam.example"}, rules.Domains.List)
+	assert.Equal(t, 5.0, rules.Domains.Score)
+	assert.Equal(t, 30*time.Minute, rules.Burst.Window)
+	assert.Equal(t, 5, rules.Burst.MinPads)
+}
+
+func TestWrite(t *testing.T) {
+	findings := []Finding{{Pad: "pad1", Score: 5.5, Flagged: true, Reasons: []string{"keyword: casino", "links: 5 (0.50 per word)"}}}
+
+	var b bytes.Buffer
+	assert.Nil(t, Write(&b, FormatTable, findings))
+	assert.Equal(t, "SCORE  FLAGGED  PAD   REASONS\n5.5    yes      pad1  keyword: casino; links: 5 (0.50 per word)\n", b.String())
+
+	b.Reset()
+	assert.Nil(t, Write(&b, FormatJSON, nil))
+	assert.Equal(t, "[]\n", b.String())
+
+	assert.EqualError(t, Write(&b, "xml", findings), "unknown format: xml")
+}
@@ -0,0 +1,125 @@
+package search
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// IndexVersion is the version of the index file format.
+const IndexVersion = 1
+
+// Document is the cached text of a pad.
+type Document struct {
+	LastEdited time.Time `json:"lastEdited"`
+	Text       string    `json:"text"`
+}
+
+// Index caches the text of pads. A document is valid as long as the pad was not edited since.
+type Index struct {
+	mu sync.Mutex
+
+	Version int                 `json:"version"`
+	Pads    map[string]Document `json:"pads"`
+}
+
+// NewIndex returns an empty Index.
+func NewIndex() *Index {
+	return &Index{Version: IndexVersion, Pads: make(map[string]Document)}
+}
+
+// LoadIndex reads the index from the file. An empty index is returned if the file does not exist.
+func LoadIndex(path string) (*Index, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return NewIndex(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	index := NewIndex()
+	if err = json.Unmarshal(b, index); err != nil {
+		return nil, err
+	}
+	if index.Version != IndexVersion {
+		return nil, fmt.Errorf("unsupported index version: %d", index.Version)
+	}
+	if index.Pads == nil {
+		index.Pads = make(map[string]Document)
+	}
+
+	return index, nil
+}
+
+// Save writes the index to the file. The file is replaced atomically.
+func (i *Index) Save(path string) error {
+	i.mu.Lock()
+	b, err := json.Marshal(i)
+	i.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// Get returns the text of the pad if it is cached for the time of the last edit.
+func (i *Index) Get(pad string, lastEdited time.Time) (string, bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	doc, ok := i.Pads[pad]
+	if !ok || !doc.LastEdited.Equal(lastEdited) {
+		return "", false
+	}
+
+	return doc.Text, true
+}
+
+// Put caches the text of the pad.
+func (i *Index) Put(pad string, lastEdited time.Time, text string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.Pads[pad] = Document{LastEdited: lastEdited, Text: text}
+}
+
+// Prune removes the pads which do not exist anymore and returns the number of removed pads.
+func (i *Index) Prune(existing []string) int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	keep := make(map[string]bool)
+	for _, pad := range existing {
+		keep[pad] = true
+	}
+
+	removed := 0
+	for pad := range i.Pads {
+		if !keep[pad] {
+			delete(i.Pads, pad)
+			removed++
+		}
+	}
+
+	return removed
+}
@@ -0,0 +1,47 @@
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+	FormatIDs  = "ids"
+)
+
+// Write writes the results in the format. The text format lists the snippets below the pads, ids only the pad IDs.
+func Write(w io.Writer, format string, results []Result) error {
+	switch format {
+	case FormatText:
+		for _, result := range results {
+			if _, err := fmt.Fprintf(w, "%s (%d matches)\n", result.Pad, result.Matches); err != nil {
+				return err
+			}
+			for _, snippet := range result.Snippets {
+				if _, err := fmt.Fprintf(w, "  %s\n", snippet); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	case FormatJSON:
+		if results == nil {
+			results = []Result{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(results)
+	case FormatIDs:
+		for _, result := range results {
+			if _, err := fmt.Fprintln(w, result.Pad); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unknown format: %s", format)
+}
@@ -0,0 +1,204 @@
+package search
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/systemli/etherpad-toolkit/pkg"
+	"github.com/systemli/etherpad-toolkit/pkg/helper"
+)
+
+// Highlight marks the matches in the snippets.
+type Highlight struct {
+	Start string
+	End   string
+}
+
+var (
+	// HighlightMarkdown marks the matches as bold text.
+	HighlightMarkdown = Highlight{Start: "**", End: "**"}
+	// HighlightANSI marks the matches in bold red for terminals.
+	HighlightANSI = Highlight{Start: "\x1b[1;31m", End: "\x1b[0m"}
+)
+
+// Result is a pad which matches the query.
+type Result struct {
+	Pad      string   `json:"pad"`
+	Matches  int      `json:"matches"`
+	Snippets []string `json:"snippets"`
+}
+
+// NewPattern returns the pattern for the query. The query is matched literally unless regex is true.
+func NewPattern(query string, regex, ignoreCase bool) (*regexp.Regexp, error) {
+	if !regex {
+		query = regexp.QuoteMeta(query)
+	}
+	if ignoreCase {
+		query = "(?i)" + query
+	}
+
+	return regexp.Compile(query)
+}
+
+type Searcher struct {
+	etherpad *pkg.Etherpad
+
+	// Index caches the text of the pads. The text is fetched for every search if nil.
+	Index *Index
+	// Concurrency is the number of pads which are fetched in parallel.
+	Concurrency int
+	// Context is the number of characters around a match in the snippets.
+	Context int
+	// Snippets is the maximum number of snippets per pad.
+	Snippets int
+	// Highlight marks the matches in the snippets.
+	Highlight Highlight
+}
+
+// NewSearcher returns a instance of Searcher.
+func NewSearcher(ep *pkg.Etherpad) *Searcher {
+	return &Searcher{
+		etherpad:    ep,
+		Concurrency: 4,
+		Context:     40,
+		Snippets:    3,
+		Highlight:   HighlightMarkdown,
+	}
+}
+
+// Search returns the pads which match the pattern, ranked by the number of matches. Pads which can not be fetched
+// are skipped.
+func (s *Searcher) Search(pads []string, pattern *regexp.Regexp) []Result {
+	var mu sync.Mutex
+	var results []Result
+	helper.ForEach(s.Concurrency, len(pads), func(i int) {
+		text, err := s.text(pads[i])
+		if err != nil {
+			log.WithError(err).WithField("pad", pads[i]).Warn("failed to fetch pad")
+			return
+		}
+
+		result, ok := s.match(pads[i], text, pattern)
+		if !ok {
+			return
+		}
+		mu.Lock()
+		results = append(results, result)
+		mu.Unlock()
+	})
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Matches != results[j].Matches {
+			return results[i].Matches > results[j].Matches
+		}
+		return results[i].Pad < results[j].Pad
+	})
+
+	return results
+}
+
+// text returns the text of the pad from the index or from Etherpad.
+func (s *Searcher) text(pad string) (string, error) {
+	if s.Index == nil {
+		return s.etherpad.GetText(pad)
+	}
+
+	lastEdited, err := s.etherpad.GetLastEdited(pad)
+	if err != nil {
+		return "", err
+	}
+	if text, ok := s.Index.Get(pad, lastEdited); ok {
+		return text, nil
+	}
+
+	text, err := s.etherpad.GetText(pad)
+	if err != nil {
+		return "", err
+	}
+	s.Index.Put(pad, lastEdited, text)
+
+	return text, nil
+}
+
+func (s *Searcher) match(pad, text string, pattern *regexp.Regexp) (Result, bool) {
+	var matches [][]int
+	for _, m := range pattern.FindAllStringIndex(text, -1) {
+		if m[1] > m[0] {
+			matches = append(matches, m)
+		}
+	}
+	if len(matches) == 0 {
+		return Result{}, false
+	}
+
+	result := Result{Pad: pad, Matches: len(matches)}
+	end := -1
+	for i, m := range matches {
+		if len(result.Snippets) >= s.Snippets {
+			break
+		}
+		if m[0] < end {
+			continue
+		}
+
+		var snippet string
+		snippet, end = s.snippet(text, matches[i:], m)
+		result.Snippets = append(result.Snippets, snippet)
+	}
+
+	return result, true
+}
+
+// snippet returns the line around the match, shortened to the context, with all matches in it highlighted. The end
+// of the snippet in the text is returned as well.
+func (s *Searcher) snippet(text string, matches [][]int, match []int) (string, int) {
+	lineStart := strings.LastIndex(text[:match[0]], "\n") + 1
+	lineEnd := len(text)
+	if i := strings.Index(text[match[1]:], "\n"); i >= 0 {
+		lineEnd = match[1] + i
+	}
+
+	start := match[0] - s.Context
+	if start < lineStart {
+		start = lineStart
+	}
+	for start > lineStart && !utf8.RuneStart(text[start]) {
+		start--
+	}
+	end := match[1] + s.Context
+	if end > lineEnd {
+		end = lineEnd
+	}
+	for end < lineEnd && !utf8.RuneStart(text[end]) {
+		end++
+	}
+
+	var b strings.Builder
+	if start > lineStart {
+		b.WriteString("…")
+	}
+	pos := start
+	for _, m := range matches {
+		if m[0] >= end {
+			break
+		}
+		if m[1] > end {
+			end = m[1]
+		}
+		b.WriteString(text[pos:m[0]])
+		b.WriteString(s.Highlight.Start)
+		b.WriteString(text[m[0]:m[1]])
+		b.WriteString(s.Highlight.End)
+		pos = m[1]
+	}
+	b.WriteString(text[pos:end])
+	if end < lineEnd {
+		b.WriteString("…")
+	}
+
+	return strings.TrimSpace(strings.ReplaceAll(b.String(), "\n", " ")), end
+}
@@ -0,0 +1,161 @@
+package search
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/systemli/etherpad-toolkit/pkg"
+)
+
+type instance struct {
+	mu    sync.Mutex
+	texts map[string]string
+	calls map[string]int
+}
+
+func (in *instance) handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		pad := r.FormValue("padID")
+		parts := strings.Split(r.URL.Path, "/")
+		method := parts[len(parts)-1]
+
+		in.mu.Lock()
+		in.calls[method]++
+		text, ok := in.texts[pad]
+		in.mu.Unlock()
+		if !ok {
+			_, _ = w.Write([]byte(`{"code": 1, "message":"padID does not exist", "data": null}`))
+			return
+		}
+
+		switch method {
+		case "getText":
+			b, _ := json.Marshal(map[string]interface{}{"code": 0, "message": "ok", "data": map[string]string{"text": text}})
+			_, _ = w.Write(b)
+		case "getLastEdited":
+			_, _ = w.Write([]byte(`{"code": 0, "message":"ok", "data": {"lastEdited": 1609556645000}}`))
+		}
+	}
+}
+
+func newInstance() *instance {
+	return &instance{
+		texts: map[string]string{
+			"pad1": "Agenda\nThe budget for 2021 was discussed. Budget approved.\nNext meeting in March",
+			"pad2": "Shopping list: milk, eggs",
+			"pad3": "The budget is fine",
+		},
+		calls: make(map[string]int),
+	}
+}
+
+func TestNewPattern(t *testing.T) {
+	pattern, err := NewPattern("a.b", false, false)
+	assert.Nil(t, err)
+	assert.True(t, pattern.MatchString("a.b"))
+	assert.False(t, pattern.MatchString("axb"))
+
+	pattern, err = NewPattern("a.b", true, true)
+	assert.Nil(t, err)
+	assert.True(t, pattern.MatchString("AXB"))
+
+	_, err = NewPattern("(", true, false)
+	assert.NotNil(t, err)
+}
+
+func TestSearcher_Search(t *testing.T) {
+	in := newInstance()
+	ts := httptest.NewServer(in.handler())
+	defer ts.Close()
+
+	searcher := NewSearcher(pkg.NewEtherpadClient(ts.URL, ""))
+	searcher.Context = 10
+
+	pattern, _ := NewPattern("budget", false, true)
+	results := searcher.Search([]string{"pad1", "pad2", "pad3", "missing"}, pattern)
+	assert.Equal(t, []Result{
+		{Pad: "pad1", Matches: 2, Snippets: []string{"The **budget** for 2021 …", "…iscussed. **Budget** approved."}},
+		{Pad: "pad3", Matches: 1, Snippets: []string{"The **budget** is fine"}},
+	}, results)
+
+	searcher.Context = 40
+	searcher.Snippets = 1
+	pattern, _ = NewPattern(`\d{4}|march`, true, true)
+	results = searcher.Search([]string{"pad1"}, pattern)
+	assert.Equal(t, []Result{{Pad: "pad1", Matches: 2, Snippets: []string{"The budget for **2021** was discussed. Budget approved."}}}, results)
+
+	pattern, _ = NewPattern(`x*`, true, false)
+	assert.Empty(t, searcher.Search([]string{"pad2"}, pattern))
+}
+
+func TestSearcher_Search_Index(t *testing.T) {
+	in := newInstance()
+	ts := httptest.NewServer(in.handler())
+	defer ts.Close()
+
+	searcher := NewSearcher(pkg.NewEtherpadClient(ts.URL, ""))
+	searcher.Index = NewIndex()
+	pattern, _ := NewPattern("milk", false, false)
+
+	assert.Len(t, searcher.Search([]string{"pad1", "pad2"}, pattern), 1)
+	assert.Equal(t, 2, in.calls["getText"])
+
+	// the pads were not edited, the text is taken from the index
+	assert.Len(t, searcher.Search([]string{"pad1", "pad2"}, pattern), 1)
+	assert.Equal(t, 2, in.calls["getText"])
+	assert.Equal(t, 4, in.calls["getLastEdited"])
+
+	searcher.Index.Put("pad2", time.Unix(0, 0), "outdated")
+	assert.Len(t, searcher.Search([]string{"pad2"}, pattern), 1)
+	assert.Equal(t, 3, in.calls["getText"])
+}
+
+func TestIndex(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index.json")
+
+	index, err := LoadIndex(path)
+	assert.Nil(t, err)
+	assert.Empty(t, index.Pads)
+
+	lastEdited := time.Unix(1609556645, 0)
+	index.Put("pad1", lastEdited, "text1")
+	index.Put("pad2", lastEdited, "text2")
+	assert.Equal(t, 1, index.Prune([]string{"pad1"}))
+	assert.Nil(t, index.Save(path))
+
+	index, err = LoadIndex(path)
+	assert.Nil(t, err)
+	text, ok := index.Get("pad1", lastEdited)
+	assert.True(t, ok)
+	assert.Equal(t, "text1", text)
+	_, ok = index.Get("pad1", lastEdited.Add(time.Second))
+	assert.False(t, ok)
+	_, ok = index.Get("pad2", lastEdited)
+	assert.False(t, ok)
+}
+
+func TestWrite(t *testing.T) {
+	results := []Result{{Pad: "pad1", Matches: 2, Snippets: []string{"The **budget**", "**Budget** approved"}}}
+
+	var b bytes.Buffer
+	assert.Nil(t, Write(&b, FormatText, results))
+	assert.Equal(t, "pad1 (2 matches)\n  The **budget**\n  **Budget** approved\n", b.String())
+
+	b.Reset()
+	assert.Nil(t, Write(&b, FormatIDs, results))
+	assert.Equal(t, "pad1\n", b.String())
+
+	b.Reset()
+	assert.Nil(t, Write(&b, FormatJSON, nil))
+	assert.Equal(t, "[]\n", b.String())
+
+	assert.EqualError(t, Write(&b, "xml", results), "unknown format: xml")
+}